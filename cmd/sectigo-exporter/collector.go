@@ -0,0 +1,347 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+const namespace = "sectigo"
+
+// Refresh sections, reported in the section label of the exporter metrics.
+const (
+	sectionSSL  = "ssl"
+	sectionDCV  = "dcv"
+	sectionACME = "acme"
+)
+
+var (
+	sslExpiryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ssl", "certificate_expiry_days"),
+		"Number of days until the SSL certificate expires, negative once expired.",
+		[]string{"ssl_id", "common_name", "org_id", "cert_type", "status"}, nil)
+	sslCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ssl", "certificates"),
+		"Number of SSL certificates by status, organization and certificate type.",
+		[]string{"status", "org_id", "cert_type"}, nil)
+	dcvExpiryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "dcv", "expiry_days"),
+		"Number of days until the domain control validation expires, negative once expired.",
+		[]string{"domain", "dcv_method", "dcv_status"}, nil)
+	acmeDomainValidDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "acme", "domain_valid_until_days"),
+		"Number of days until the ACME account domain validation expires, negative once expired.",
+		[]string{"org_id", "account_id", "account_name", "domain"}, nil)
+	refreshSuccessDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "exporter", "refresh_success"),
+		"Whether the last refresh of the section succeeded.",
+		[]string{"section"}, nil)
+	refreshTimestampDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "exporter", "refresh_timestamp_seconds"),
+		"Unix time of the last successful refresh of the section.",
+		[]string{"section"}, nil)
+	errorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "exporter", "errors_total"),
+		"Number of items skipped by the refreshes of the section after an error.",
+		[]string{"section"}, nil)
+	refreshDurationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "exporter", "refresh_duration_seconds"),
+		"Duration of the last refresh.",
+		nil, nil)
+)
+
+// dateLayouts are the layouts of the dates returned by the Sectigo API.
+var dateLayouts = []string{
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02T15:04:05.000-07:00",
+	"2006-01-02T15:04:05",
+	"01/02/2006",
+}
+
+// parseDate parses a date returned by the Sectigo API.
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported date format: %q", value)
+}
+
+// acmeDomain is an ACME account domain with the account it belongs to.
+type acmeDomain struct {
+	account sectigo.AcmeAccount
+	domain  sectigo.AcmeAccountDomain
+}
+
+// sectionState holds the last successfully refreshed data of a section.
+type sectionState struct {
+	success     bool
+	lastSuccess time.Time
+}
+
+// collector periodically fetches the certificates, domain validations and ACME domains from the Sectigo
+// API and exposes them as Prometheus metrics computed at scrape time.
+type collector struct {
+	client             *sectigo.Client
+	detailsConcurrency int
+	logger             *slog.Logger
+	now                func() time.Time
+
+	mu              sync.RWMutex
+	certificates    []sectigo.SSLDetails
+	validations     []sectigo.DomainValidation
+	acmeDomains     []acmeDomain
+	sections        map[string]*sectionState
+	errors          map[string]int
+	refreshDuration time.Duration
+}
+
+// newCollector creates a collector fetching certificate details with up to detailsConcurrency requests in parallel.
+func newCollector(client *sectigo.Client, detailsConcurrency int, logger *slog.Logger) *collector {
+	return &collector{
+		client:             client,
+		detailsConcurrency: max(detailsConcurrency, 1),
+		logger:             logger,
+		now:                time.Now,
+		sections: map[string]*sectionState{
+			sectionSSL:  {},
+			sectionDCV:  {},
+			sectionACME: {},
+		},
+		errors: map[string]int{sectionSSL: 0},
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sslExpiryDesc
+	ch <- sslCountDesc
+	ch <- dcvExpiryDesc
+	ch <- acmeDomainValidDesc
+	ch <- refreshSuccessDesc
+	ch <- refreshTimestampDesc
+	ch <- errorsDesc
+	ch <- refreshDurationDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := c.now()
+
+	type sslKey struct{ status, orgId, certType string }
+	counts := map[sslKey]int{}
+	for _, cert := range c.certificates {
+		orgId := strconv.Itoa(cert.OrgId)
+		counts[sslKey{cert.Status, orgId, cert.CertType.Name}]++
+
+		if days, ok := c.daysUntil(now, cert.Expires); ok {
+			ch <- prometheus.MustNewConstMetric(sslExpiryDesc, prometheus.GaugeValue, days,
+				strconv.Itoa(cert.SSLId), cert.CommonName, orgId, cert.CertType.Name, cert.Status)
+		}
+	}
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(sslCountDesc, prometheus.GaugeValue, float64(count),
+			key.status, key.orgId, key.certType)
+	}
+
+	for _, validation := range c.validations {
+		if days, ok := c.daysUntil(now, validation.ExpirationDate); ok {
+			ch <- prometheus.MustNewConstMetric(dcvExpiryDesc, prometheus.GaugeValue, days,
+				validation.Domain, validation.DcvMethod, validation.DcvStatus)
+		}
+	}
+
+	for _, d := range c.acmeDomains {
+		if days, ok := c.daysUntil(now, d.domain.ValidUntil); ok {
+			ch <- prometheus.MustNewConstMetric(acmeDomainValidDesc, prometheus.GaugeValue, days,
+				strconv.Itoa(d.account.OrganizationID), strconv.Itoa(d.account.ID), d.account.Name, d.domain.Name)
+		}
+	}
+
+	for section, state := range c.sections {
+		success := 0.0
+		if state.success {
+			success = 1
+		}
+		ch <- prometheus.MustNewConstMetric(refreshSuccessDesc, prometheus.GaugeValue, success, section)
+		if !state.lastSuccess.IsZero() {
+			ch <- prometheus.MustNewConstMetric(refreshTimestampDesc, prometheus.GaugeValue,
+				float64(state.lastSuccess.Unix()), section)
+		}
+	}
+	for section, count := range c.errors {
+		ch <- prometheus.MustNewConstMetric(errorsDesc, prometheus.CounterValue, float64(count), section)
+	}
+	ch <- prometheus.MustNewConstMetric(refreshDurationDesc, prometheus.GaugeValue, c.refreshDuration.Seconds())
+}
+
+// daysUntil returns the number of days between now and the date value, or false if value is empty or invalid.
+func (c *collector) daysUntil(now time.Time, value string) (float64, bool) {
+	if value == "" {
+		return 0, false
+	}
+	t, err := parseDate(value)
+	if err != nil {
+		c.logger.Debug("ignoring invalid date", "value", value, "error", err)
+		return 0, false
+	}
+	return t.Sub(now).Hours() / 24, true
+}
+
+// run refreshes the collector every interval until ctx is done.
+func (c *collector) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.refresh(ctx); err != nil {
+			c.logger.Error("error refreshing metrics", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh fetches all sections from the Sectigo API. A failing section keeps its previous data.
+func (c *collector) refresh(ctx context.Context) error {
+	start := c.now()
+
+	certificates, skipped, sslErr := c.fetchCertificates(ctx)
+	validations, dcvErr := c.client.ListAllDomainValidation(ctx, sectigo.ListDomainValidationParams{})
+	acmeDomains, acmeErr := c.fetchAcmeDomains(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.errors[sectionSSL] += skipped
+	if c.update(sectionSSL, sslErr, start) {
+		c.certificates = certificates
+	}
+	if c.update(sectionDCV, dcvErr, start) {
+		c.validations = validations
+	}
+	if c.update(sectionACME, acmeErr, start) {
+		c.acmeDomains = acmeDomains
+	}
+	c.refreshDuration = c.now().Sub(start)
+
+	return errors.Join(
+		wrapSectionError(sectionSSL, sslErr),
+		wrapSectionError(sectionDCV, dcvErr),
+		wrapSectionError(sectionACME, acmeErr),
+	)
+}
+
+// update records the outcome of the refresh of section and reports whether it succeeded.
+func (c *collector) update(section string, err error, at time.Time) bool {
+	state := c.sections[section]
+	state.success = err == nil
+	if state.success {
+		state.lastSuccess = at
+	}
+	return state.success
+}
+
+// wrapSectionError annotates err with the section it occurred in.
+func wrapSectionError(section string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("error refreshing %s: %w", section, err)
+}
+
+// fetchCertificates lists all SSL certificates and fetches their details. Certificates whose details cannot be
+// fetched, such as certificates deleted since the listing, are skipped and their number returned.
+func (c *collector) fetchCertificates(ctx context.Context) ([]sectigo.SSLDetails, int, error) {
+	certificates, err := c.client.ListAllSSL(ctx, sectigo.ListSSLParams{})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	details := make([]sectigo.SSLDetails, len(certificates))
+	errs := make([]error, len(certificates))
+	sem := make(chan struct{}, c.detailsConcurrency)
+	var wg sync.WaitGroup
+launch:
+	for i, cert := range certificates {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break launch
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			d, err := c.client.GetSSLDetails(ctx, cert.SSLId)
+			if err != nil {
+				errs[i] = fmt.Errorf("error getting details of certificate %d: %w", cert.SSLId, err)
+				return
+			}
+			details[i] = *d
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	fetched := make([]sectigo.SSLDetails, 0, len(details))
+	skipped := 0
+	for i, err := range errs {
+		if err != nil {
+			c.logger.Warn("skipping certificate", "error", err)
+			skipped++
+			continue
+		}
+		fetched = append(fetched, details[i])
+	}
+	return fetched, skipped, nil
+}
+
+// fetchAcmeDomains lists the domains of the ACME accounts of every organization.
+func (c *collector) fetchAcmeDomains(ctx context.Context) ([]acmeDomain, error) {
+	organizations, err := c.client.ListOrganization(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var domains []acmeDomain
+	for _, org := range *organizations {
+		accounts, err := c.client.ListAllAcmeAccount(ctx, sectigo.ListAcmeAccountParams{OrganizationId: org.ID})
+		if err != nil {
+			return nil, fmt.Errorf("error listing ACME accounts of organization %d: %w", org.ID, err)
+		}
+
+		for _, account := range accounts {
+			accountDomains, err := c.client.ListAllAcmeAccountDomain(ctx, sectigo.ListAcmeAccountDomainParams{AccountID: account.ID})
+			if err != nil {
+				return nil, fmt.Errorf("error listing domains of ACME account %d: %w", account.ID, err)
+			}
+			for _, domain := range accountDomains {
+				domains = append(domains, acmeDomain{account: account, domain: domain})
+			}
+		}
+	}
+
+	return domains, nil
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	writeJSON := func(w http.ResponseWriter, total int, v any) {
+		if total > 0 {
+			w.Header().Set("X-Total-Count", strconv.Itoa(total))
+		}
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	mux.HandleFunc("/api/ssl/v1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, 2, []sectigo.SSLCertificate{{SSLId: 1}, {SSLId: 2}})
+	})
+	mux.HandleFunc("/api/ssl/v1/1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, 0, sectigo.SSLDetails{SSLId: 1, CommonName: "a.example.com", OrgId: 10, Status: "Issued",
+			CertType: sectigo.CertType{Name: "OV SSL"}, Expires: "2025-01-31"})
+	})
+	mux.HandleFunc("/api/ssl/v1/2", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, 0, sectigo.SSLDetails{SSLId: 2, CommonName: "b.example.com", OrgId: 10, Status: "Requested",
+			CertType: sectigo.CertType{Name: "OV SSL"}})
+	})
+	mux.HandleFunc("/api/dcv/v1/validation", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, 1, []sectigo.DomainValidation{{Domain: "example.com", DcvMethod: "CNAME", DcvStatus: "VALIDATED",
+			ExpirationDate: "2025-01-11"}})
+	})
+	mux.HandleFunc("/api/organization/v1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, 0, []sectigo.Organization{{ID: 10, Name: "Org"}})
+	})
+	mux.HandleFunc("/api/acme/v2/account", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "10", r.URL.Query().Get("organizationId"))
+		writeJSON(w, 1, []sectigo.AcmeAccount{{ID: 5, Name: "acme", OrganizationID: 10}})
+	})
+	mux.HandleFunc("/api/acme/v2/account/5/domain", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, 1, []sectigo.AcmeAccountDomain{{Name: "acme.example.com", ValidUntil: "2025-01-31"}})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestCollector(url string) *collector {
+	client := sectigo.NewClient(sectigo.Config{
+		URL:      url,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+	})
+	c := newCollector(client, 2, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	c.now = func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+	return c
+}
+
+func TestCollector(t *testing.T) {
+	server := newTestServer(t)
+	c := newTestCollector(server.URL)
+
+	assert.NoError(t, c.refresh(context.Background()))
+
+	expected := `
+# HELP sectigo_acme_domain_valid_until_days Number of days until the ACME account domain validation expires, negative once expired.
+# TYPE sectigo_acme_domain_valid_until_days gauge
+sectigo_acme_domain_valid_until_days{account_id="5",account_name="acme",domain="acme.example.com",org_id="10"} 30
+# HELP sectigo_dcv_expiry_days Number of days until the domain control validation expires, negative once expired.
+# TYPE sectigo_dcv_expiry_days gauge
+sectigo_dcv_expiry_days{dcv_method="CNAME",dcv_status="VALIDATED",domain="example.com"} 10
+# HELP sectigo_ssl_certificate_expiry_days Number of days until the SSL certificate expires, negative once expired.
+# TYPE sectigo_ssl_certificate_expiry_days gauge
+sectigo_ssl_certificate_expiry_days{cert_type="OV SSL",common_name="a.example.com",org_id="10",ssl_id="1",status="Issued"} 30
+# HELP sectigo_ssl_certificates Number of SSL certificates by status, organization and certificate type.
+# TYPE sectigo_ssl_certificates gauge
+sectigo_ssl_certificates{cert_type="OV SSL",org_id="10",status="Issued"} 1
+sectigo_ssl_certificates{cert_type="OV SSL",org_id="10",status="Requested"} 1
+# HELP sectigo_exporter_refresh_success Whether the last refresh of the section succeeded.
+# TYPE sectigo_exporter_refresh_success gauge
+sectigo_exporter_refresh_success{section="acme"} 1
+sectigo_exporter_refresh_success{section="dcv"} 1
+sectigo_exporter_refresh_success{section="ssl"} 1
+`
+	err := testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"sectigo_ssl_certificate_expiry_days", "sectigo_ssl_certificates", "sectigo_dcv_expiry_days",
+		"sectigo_acme_domain_valid_until_days", "sectigo_exporter_refresh_success")
+	assert.NoError(t, err)
+}
+
+func TestCollector_SectionFailureKeepsPreviousData(t *testing.T) {
+	server := newTestServer(t)
+	c := newTestCollector(server.URL)
+	assert.NoError(t, c.refresh(context.Background()))
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/dcv/v1/validation" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		server.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer failing.Close()
+	c.client.BaseURL = failing.URL
+
+	err := c.refresh(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error refreshing dcv")
+
+	expected := `
+# HELP sectigo_dcv_expiry_days Number of days until the domain control validation expires, negative once expired.
+# TYPE sectigo_dcv_expiry_days gauge
+sectigo_dcv_expiry_days{dcv_method="CNAME",dcv_status="VALIDATED",domain="example.com"} 10
+# HELP sectigo_exporter_refresh_success Whether the last refresh of the section succeeded.
+# TYPE sectigo_exporter_refresh_success gauge
+sectigo_exporter_refresh_success{section="acme"} 1
+sectigo_exporter_refresh_success{section="dcv"} 0
+sectigo_exporter_refresh_success{section="ssl"} 1
+`
+	err = testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"sectigo_dcv_expiry_days", "sectigo_exporter_refresh_success")
+	assert.NoError(t, err)
+}
+
+func TestCollector_CertificateFailureSkipsCertificate(t *testing.T) {
+	server := newTestServer(t)
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/ssl/v1/2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		server.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer failing.Close()
+	c := newTestCollector(failing.URL)
+
+	assert.NoError(t, c.refresh(context.Background()))
+	assert.NoError(t, c.refresh(context.Background()))
+
+	expected := `
+# HELP sectigo_exporter_errors_total Number of items skipped by the refreshes of the section after an error.
+# TYPE sectigo_exporter_errors_total counter
+sectigo_exporter_errors_total{section="ssl"} 2
+# HELP sectigo_ssl_certificate_expiry_days Number of days until the SSL certificate expires, negative once expired.
+# TYPE sectigo_ssl_certificate_expiry_days gauge
+sectigo_ssl_certificate_expiry_days{cert_type="OV SSL",common_name="a.example.com",org_id="10",ssl_id="1",status="Issued"} 30
+# HELP sectigo_exporter_refresh_success Whether the last refresh of the section succeeded.
+# TYPE sectigo_exporter_refresh_success gauge
+sectigo_exporter_refresh_success{section="acme"} 1
+sectigo_exporter_refresh_success{section="dcv"} 1
+sectigo_exporter_refresh_success{section="ssl"} 1
+`
+	err := testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"sectigo_exporter_errors_total", "sectigo_ssl_certificate_expiry_days", "sectigo_exporter_refresh_success")
+	assert.NoError(t, err)
+}
+
+func TestFetchCertificates_Canceled(t *testing.T) {
+	server := newTestServer(t)
+	c := newTestCollector(server.URL)
+	assert.NoError(t, c.refresh(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	certificates, skipped, err := c.fetchCertificates(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, certificates)
+	assert.Zero(t, skipped)
+}
+
+func TestParseDate(t *testing.T) {
+	for _, value := range []string{"2025-01-31", "2025-01-31T00:00:00Z", "2025-01-31T00:00:00.000+00:00", "01/31/2025"} {
+		d, err := parseDate(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, 31, d.Day(), value)
+	}
+
+	_, err := parseDate("31 Jan")
+	assert.Error(t, err)
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("SECTIGO_URL", "")
+	t.Setenv("SECTIGO_USERNAME", "user")
+	t.Setenv("SECTIGO_CUSTOMER", "customer")
+	t.Setenv("SECTIGO_PASSWORD", "")
+	t.Setenv("SECTIGO_PASSWORD_FILE", "")
+
+	_, err := configFromEnv()
+	assert.Error(t, err)
+
+	t.Setenv("SECTIGO_PASSWORD", "secret")
+	config, err := configFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, defaultURL, config.URL)
+	assert.Equal(t, "secret", config.Password)
+}
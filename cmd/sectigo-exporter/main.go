@@ -0,0 +1,138 @@
+// Command sectigo-exporter exposes Prometheus metrics about the certificates, domain control validations and
+// ACME account domains of a Sectigo Certificate Manager account.
+//
+// The connection to the Sectigo API is configured with the following environment variables:
+//
+//	SECTIGO_URL            base URL of the API, defaults to https://cert-manager.com
+//	SECTIGO_USERNAME       login of the API account
+//	SECTIGO_CUSTOMER       customer URI
+//	SECTIGO_PASSWORD       password of the API account
+//	SECTIGO_PASSWORD_FILE  file holding the password, read instead of SECTIGO_PASSWORD when set
+//
+// Exposed metrics:
+//
+//	sectigo_ssl_certificate_expiry_days     days until each certificate expires
+//	sectigo_ssl_certificates                number of certificates by status, organization and certificate type
+//	sectigo_dcv_expiry_days                 days until each domain control validation expires
+//	sectigo_acme_domain_valid_until_days    days until each ACME account domain validation expires
+//	sectigo_exporter_refresh_success        whether the last refresh of each section succeeded
+//	sectigo_exporter_refresh_timestamp_seconds  time of the last successful refresh of each section
+//	sectigo_exporter_refresh_duration_seconds   duration of the last refresh
+//	sectigo_exporter_errors_total          number of certificates skipped because their details could not be fetched
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+const defaultURL = "https://cert-manager.com"
+
+func main() {
+	var (
+		listenAddress      = flag.String("web.listen-address", ":9793", "Address on which to expose metrics.")
+		metricsPath        = flag.String("web.telemetry-path", "/metrics", "Path under which to expose metrics.")
+		refreshInterval    = flag.Duration("refresh-interval", 15*time.Minute, "Interval between two refreshes of the Sectigo data.")
+		detailsConcurrency = flag.Int("details-concurrency", 4, "Maximum number of certificate details fetched in parallel.")
+		pageConcurrency    = flag.Int("page-concurrency", 1, "Maximum number of list pages fetched in parallel.")
+		rateLimit          = flag.Float64("rate-limit", 0, "Maximum number of Sectigo API requests per second, 0 for unlimited.")
+		debug              = flag.Bool("debug", false, "Enable debug logging.")
+	)
+	flag.Parse()
+
+	level := slog.LevelInfo
+	if *debug {
+		level = slog.LevelDebug
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+
+	if err := run(logger, *listenAddress, *metricsPath, *refreshInterval, *detailsConcurrency, *pageConcurrency, *rateLimit); err != nil {
+		logger.Error("exporter failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(logger *slog.Logger, listenAddress, metricsPath string, refreshInterval time.Duration, detailsConcurrency, pageConcurrency int, rateLimit float64) error {
+	config, err := configFromEnv()
+	if err != nil {
+		return err
+	}
+	config.Logger = logger
+	config.PageConcurrency = pageConcurrency
+	config.RateLimit = rateLimit
+	config.Retry = sectigo.DefaultRetryPolicy()
+
+	client, err := sectigo.NewClientWithOptions(config, sectigo.WithUserAgent("sectigo-exporter"))
+	if err != nil {
+		return fmt.Errorf("error creating Sectigo client: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c := newCollector(client, detailsConcurrency, logger)
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(c, collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+	go c.run(ctx, refreshInterval)
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Addr:              listenAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("listening", "address", listenAddress, "path", metricsPath)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// configFromEnv builds the Sectigo client configuration from the environment.
+func configFromEnv() (sectigo.Config, error) {
+	config := sectigo.Config{
+		URL:      os.Getenv("SECTIGO_URL"),
+		Username: os.Getenv("SECTIGO_USERNAME"),
+		Customer: os.Getenv("SECTIGO_CUSTOMER"),
+		Password: os.Getenv("SECTIGO_PASSWORD"),
+	}
+	if config.URL == "" {
+		config.URL = defaultURL
+	}
+	if path := os.Getenv("SECTIGO_PASSWORD_FILE"); path != "" {
+		config.CredentialProvider = sectigo.NewFileCredentialProvider(path)
+	}
+
+	if config.Username == "" || config.Customer == "" {
+		return config, errors.New("SECTIGO_USERNAME and SECTIGO_CUSTOMER must be set")
+	}
+	if config.Password == "" && config.CredentialProvider == nil {
+		return config, errors.New("SECTIGO_PASSWORD or SECTIGO_PASSWORD_FILE must be set")
+	}
+
+	return config, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+var acmeCommands = map[string]command{
+	"accounts": {
+		usage:       "-org-id id [-name name] [-status status]",
+		description: "List the ACME accounts of an organization.",
+		run:         acmeAccounts,
+	},
+	"domains": {
+		usage:       "[-name name] [-expires-within days] <account-id>",
+		description: "List the domains of an ACME account.",
+		run:         acmeDomains,
+	},
+	"add-domains": {
+		usage:       "<account-id> <domain>...",
+		description: "Add domains to an ACME account.",
+		run:         acmeAddDomains,
+	},
+}
+
+func acmeAccounts(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	var params sectigo.ListAcmeAccountParams
+	flags.IntVar(&params.OrganizationId, "org-id", 0, "Organization ID.")
+	flags.StringVar(&params.Name, "name", "", "Filter by name.")
+	flags.StringVar(&params.Status, "status", "", "Filter by status.")
+	if err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	accounts, err := app.client.ListAllAcmeAccount(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	return printList(app.out, accounts, []column[sectigo.AcmeAccount]{
+		{"ID", func(a sectigo.AcmeAccount) any { return a.ID }},
+		{"NAME", func(a sectigo.AcmeAccount) any { return a.Name }},
+		{"STATUS", func(a sectigo.AcmeAccount) any { return a.Status }},
+		{"VALIDATION", func(a sectigo.AcmeAccount) any { return a.CertValidationType }},
+		{"SERVER", func(a sectigo.AcmeAccount) any { return a.AcmeServer }},
+	})
+}
+
+func acmeDomains(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	var params sectigo.ListAcmeAccountDomainParams
+	flags.StringVar(&params.Name, "name", "", "Filter by name.")
+	flags.IntVar(&params.ExpiresWithinNextDays, "expires-within", 0, "Only list domains expiring within this number of days.")
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+
+	var err error
+	params.AccountID, err = parseID(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	domains, err := app.client.ListAllAcmeAccountDomain(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	return printList(app.out, domains, []column[sectigo.AcmeAccountDomain]{
+		{"NAME", func(d sectigo.AcmeAccountDomain) any { return d.Name }},
+		{"VALID UNTIL", func(d sectigo.AcmeAccountDomain) any { return d.ValidUntil }},
+		{"STICKY UNTIL", func(d sectigo.AcmeAccountDomain) any { return d.StickyUntil }},
+	})
+}
+
+func acmeAddDomains(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < 2 {
+		flags.Usage()
+		return errUsage
+	}
+
+	accountId, err := parseID(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	params := sectigo.AcmeAccountDomainParams{AccountID: accountId, Domains: flags.Args()[1:]}
+	if err := app.client.AddAcmeAccountDomains(ctx, params); err != nil {
+		return err
+	}
+	return app.out.printMessage("%d domains added to ACME account %d", len(params.Domains), accountId)
+}
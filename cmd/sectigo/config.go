@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+const defaultURL = "https://cert-manager.com"
+
+// fileConfig is the content of the configuration file.
+type fileConfig struct {
+	URL          string `yaml:"url"`
+	Username     string `yaml:"username"`
+	Customer     string `yaml:"customer"`
+	Password     string `yaml:"password"`
+	PasswordFile string `yaml:"password_file"`
+}
+
+// defaultConfigPath returns the path of the configuration file used when none is set.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "sectigo", "config.yaml")
+}
+
+// loadConfig builds the client configuration from the configuration file at path, or the default one if
+// path is empty, overridden by the environment. A missing default configuration file is ignored.
+func loadConfig(path string) (sectigo.Config, error) {
+	var file fileConfig
+
+	explicit := path != ""
+	if !explicit {
+		path = defaultConfigPath()
+	}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(data, &file); err != nil {
+				return sectigo.Config{}, fmt.Errorf("error parsing configuration file %s: %w", path, err)
+			}
+		case explicit || !errors.Is(err, fs.ErrNotExist):
+			return sectigo.Config{}, fmt.Errorf("error reading configuration file: %w", err)
+		}
+	}
+
+	for env, value := range map[string]*string{
+		"SECTIGO_URL":           &file.URL,
+		"SECTIGO_USERNAME":      &file.Username,
+		"SECTIGO_CUSTOMER":      &file.Customer,
+		"SECTIGO_PASSWORD":      &file.Password,
+		"SECTIGO_PASSWORD_FILE": &file.PasswordFile,
+	} {
+		if v := os.Getenv(env); v != "" {
+			*value = v
+		}
+	}
+
+	config := sectigo.Config{
+		URL:      file.URL,
+		Username: file.Username,
+		Customer: file.Customer,
+		Password: file.Password,
+	}
+	if config.URL == "" {
+		config.URL = defaultURL
+	}
+	if file.PasswordFile != "" {
+		config.CredentialProvider = sectigo.NewFileCredentialProvider(file.PasswordFile)
+	}
+
+	if config.Username == "" || config.Customer == "" {
+		return config, errors.New("username and customer must be set in the configuration file or with SECTIGO_USERNAME and SECTIGO_CUSTOMER")
+	}
+	if config.Password == "" && config.CredentialProvider == nil {
+		return config, errors.New("password must be set in the configuration file or with SECTIGO_PASSWORD or SECTIGO_PASSWORD_FILE")
+	}
+
+	return config, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+// DCV methods supported by the dcv commands.
+const (
+	dcvMethodCNAME = "cname"
+	dcvMethodHTTP  = "http"
+	dcvMethodHTTPS = "https"
+	dcvMethodTXT   = "txt"
+	dcvMethodEmail = "email"
+)
+
+var dcvCommands = map[string]command{
+	"start": {
+		usage:       "[-method cname|http|https|txt|email] <domain>",
+		description: "Start the domain control validation of a domain.",
+		run:         dcvStart,
+	},
+	"submit": {
+		usage:       "[-method cname|http|https|txt|email] [-email approver] <domain>",
+		description: "Submit the domain control validation of a domain.",
+		run:         dcvSubmit,
+	},
+	"status": {
+		usage:       "<domain>",
+		description: "Show the domain control validation status of a domain.",
+		run:         dcvStatus,
+	},
+}
+
+func dcvStart(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	method := flags.String("method", dcvMethodCNAME, "Validation method.")
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+
+	domain := flags.Arg(0)
+	var resp any
+	var err error
+	switch *method {
+	case dcvMethodCNAME:
+		resp, err = app.client.StartDomainCNameValidation(ctx, sectigo.StartDomainCNameValidationRequest{Domain: domain})
+	case dcvMethodHTTP:
+		resp, err = app.client.StartDomainHTTPValidation(ctx, sectigo.StartDomainHTTPValidationRequest{Domain: domain})
+	case dcvMethodHTTPS:
+		resp, err = app.client.StartDomainHTTPSValidation(ctx, sectigo.StartDomainHTTPValidationRequest{Domain: domain})
+	case dcvMethodTXT:
+		resp, err = app.client.StartDomainTXTValidation(ctx, sectigo.StartDomainTXTValidationRequest{Domain: domain})
+	case dcvMethodEmail:
+		resp, err = app.client.StartDomainEmailValidation(ctx, sectigo.StartDomainEmailValidationRequest{Domain: domain})
+	default:
+		return fmt.Errorf("unsupported validation method %q", *method)
+	}
+	if err != nil {
+		return err
+	}
+	return app.out.printObject(resp)
+}
+
+func dcvSubmit(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	method := flags.String("method", dcvMethodCNAME, "Validation method.")
+	email := flags.String("email", "", "Approver email, required by the email method.")
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+
+	domain := flags.Arg(0)
+	var resp any
+	var err error
+	switch *method {
+	case dcvMethodCNAME:
+		resp, err = app.client.SubmitDomainCNameValidation(ctx, sectigo.SubmitDomainCNameValidationRequest{Domain: domain})
+	case dcvMethodHTTP:
+		resp, err = app.client.SubmitDomainHTTPValidation(ctx, sectigo.SubmitDomainHTTPValidationRequest{Domain: domain})
+	case dcvMethodHTTPS:
+		resp, err = app.client.SubmitDomainHTTPSValidation(ctx, sectigo.SubmitDomainHTTPValidationRequest{Domain: domain})
+	case dcvMethodTXT:
+		resp, err = app.client.SubmitDomainTXTValidation(ctx, sectigo.SubmitDomainTXTValidationRequest{Domain: domain})
+	case dcvMethodEmail:
+		if *email == "" {
+			return errors.New("-email is required by the email method")
+		}
+		resp, err = app.client.SubmitDomainEmailValidation(ctx, sectigo.SubmitDomainEmailValidationRequest{Domain: domain, Email: *email})
+	default:
+		return fmt.Errorf("unsupported validation method %q", *method)
+	}
+	if err != nil {
+		return err
+	}
+	return app.out.printObject(resp)
+}
+
+func dcvStatus(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+
+	resp, err := app.client.GetDomainValidationStatus(ctx, sectigo.GetDomainValidationStatusRequest{Domain: flags.Arg(0)})
+	if err != nil {
+		return err
+	}
+	return app.out.printObject(resp)
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+var domainCommands = map[string]command{
+	"list": {
+		usage:       "[-name name] [-state state] [-status status] [-org-id id]",
+		description: "List domains.",
+		run:         domainList,
+	},
+	"get": {
+		usage:       "<domain-id>",
+		description: "Show the details of a domain.",
+		run:         domainGet,
+	},
+	"create": {
+		usage:       "[-description text] [-inactive] [-org-id id -cert-types types] <name>",
+		description: "Create a domain.",
+		run:         domainCreate,
+	},
+	"update": {
+		usage:       "[-description text] [-active bool] <domain-id>",
+		description: "Update the description or the active state of a domain.",
+		run:         domainUpdate,
+	},
+	"suspend": {
+		usage:       "<domain-id>",
+		description: "Suspend a domain.",
+		run:         domainSuspend,
+	},
+	"activate": {
+		usage:       "<domain-id>",
+		description: "Activate a suspended domain.",
+		run:         domainActivate,
+	},
+	"delete": {
+		usage:       "<domain-id>",
+		description: "Delete a domain.",
+		run:         domainDelete,
+	},
+	"delegate": {
+		usage:       "-org-id id [-cert-types types] <domain-id>...",
+		description: "Delegate domains to an organization.",
+		run:         domainDelegate,
+	},
+	"approve": {
+		usage:       "-org-id id <domain-id>",
+		description: "Approve the delegation of a domain to an organization.",
+		run:         domainApprove,
+	},
+}
+
+func domainList(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	var params sectigo.ListDomainParams
+	flags.StringVar(&params.Name, "name", "", "Filter by name.")
+	flags.StringVar(&params.State, "state", "", "Filter by state.")
+	flags.StringVar(&params.Status, "status", "", "Filter by status.")
+	flags.IntVar(&params.OrgId, "org-id", 0, "Filter by organization ID.")
+	if err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	domains, err := app.client.ListAllDomain(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	return printList(app.out, domains, []column[sectigo.Domain]{
+		{"ID", func(d sectigo.Domain) any { return d.ID }},
+		{"NAME", func(d sectigo.Domain) any { return d.Name }},
+	})
+}
+
+func domainGet(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+	domainId, err := parseID(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	details, err := app.client.GetDomainDetails(ctx, domainId)
+	if err != nil {
+		return err
+	}
+	return app.out.printObject(details)
+}
+
+func domainCreate(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	description := flags.String("description", "", "Description of the domain.")
+	inactive := flags.Bool("inactive", false, "Create the domain inactive.")
+	orgId := flags.Int("org-id", 0, "Organization to delegate the domain to.")
+	certTypes := flags.String("cert-types", "SSL", "Comma separated certificate types delegated with -org-id.")
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+
+	request := sectigo.DomainRequest{
+		Name:        flags.Arg(0),
+		Description: *description,
+		Active:      !*inactive,
+		Delegations: []sectigo.DelegationRequest{},
+	}
+	if *orgId != 0 {
+		request.Delegations = append(request.Delegations, sectigo.DelegationRequest{OrgId: *orgId, CertTypes: splitList(*certTypes)})
+	}
+
+	domainId, err := app.client.CreateDomainWithID(ctx, request)
+	if err != nil {
+		return err
+	}
+	return app.out.printMessage("Domain %s created with ID %d", request.Name, domainId)
+}
+
+func domainUpdate(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	var request sectigo.UpdateDomainRequest
+	flags.Func("description", "New description of the domain.", func(value string) error {
+		request.Description = &value
+		return nil
+	})
+	flags.Func("active", "New active state of the domain, true or false.", func(value string) error {
+		active, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		request.Active = &active
+		return nil
+	})
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+	domainId, err := parseID(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if err := app.client.UpdateDomain(ctx, domainId, request); err != nil {
+		return err
+	}
+	return app.out.printMessage("Domain %d updated", domainId)
+}
+
+func domainSuspend(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+	domainId, err := parseID(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if err := app.client.SuspendDomain(ctx, domainId); err != nil {
+		return err
+	}
+	return app.out.printMessage("Domain %d suspended", domainId)
+}
+
+func domainActivate(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+	domainId, err := parseID(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if err := app.client.ActivateDomain(ctx, domainId); err != nil {
+		return err
+	}
+	return app.out.printMessage("Domain %d activated", domainId)
+}
+
+func domainDelete(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+	domainId, err := parseID(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if err := app.client.DeleteDomain(ctx, domainId); err != nil {
+		return err
+	}
+	return app.out.printMessage("Domain %d deleted", domainId)
+}
+
+func domainDelegate(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	orgId := flags.Int("org-id", 0, "Organization to delegate the domains to.")
+	certTypes := flags.String("cert-types", "SSL", "Comma separated certificate types to delegate.")
+	if err := parseArgs(flags, args, -1); err != nil {
+		return err
+	}
+	if *orgId == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	request := sectigo.DelegateDomainRequest{OrgId: *orgId, CertTypes: splitList(*certTypes)}
+	for _, arg := range flags.Args() {
+		domainId, err := parseID(arg)
+		if err != nil {
+			return err
+		}
+		request.DomainIds = append(request.DomainIds, domainId)
+	}
+
+	if err := app.client.DelegateDomain(ctx, request); err != nil {
+		return err
+	}
+	return app.out.printMessage("Domains delegated to organization %d", *orgId)
+}
+
+func domainApprove(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	orgId := flags.Int("org-id", 0, "Organization the domain is delegated to.")
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+	if *orgId == 0 {
+		flags.Usage()
+		return errUsage
+	}
+	domainId, err := parseID(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if err := app.client.ApproveDelegation(ctx, domainId, sectigo.ApproveDelegationRequest{OrgId: *orgId}); err != nil {
+		return err
+	}
+	return app.out.printMessage("Delegation of domain %d to organization %d approved", domainId, *orgId)
+}
@@ -0,0 +1,19 @@
+module github.com/fgouteroux/sectigo-client/cmd/sectigo
+
+go 1.24.0
+
+require (
+	github.com/fgouteroux/sectigo-client v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.11.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/crypto v0.11.0 // indirect
+	software.sslmate.com/src/go-pkcs12 v0.7.3 // indirect
+)
+
+replace github.com/fgouteroux/sectigo-client => ../..
@@ -0,0 +1,152 @@
+// Command sectigo is a command line interface to the Sectigo Certificate Manager API.
+//
+// Usage:
+//
+//	sectigo [-config file] [-o table|json|yaml] [-debug] <group> <command> [flags] [args]
+//
+// Credentials are read from the configuration file, by default $HOME/.config/sectigo/config.yaml or the file
+// set in SECTIGO_CONFIG, and overridden by the SECTIGO_URL, SECTIGO_USERNAME, SECTIGO_CUSTOMER,
+// SECTIGO_PASSWORD and SECTIGO_PASSWORD_FILE environment variables.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+// errUsage is returned when the command line is invalid, after the usage has been printed.
+var errUsage = errors.New("invalid usage")
+
+// command is a CLI subcommand.
+type command struct {
+	usage       string
+	description string
+	run         func(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error
+}
+
+// app holds the state shared by the subcommands.
+type app struct {
+	client *sectigo.Client
+	out    *printer
+}
+
+// commands holds the subcommands by group and name.
+var commands = map[string]map[string]command{
+	"ssl":    sslCommands,
+	"domain": domainCommands,
+	"dcv":    dcvCommands,
+	"acme":   acmeCommands,
+	"org":    orgCommands,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, errUsage) && !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run parses the command line and runs the selected subcommand.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("sectigo", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configPath := flags.String("config", os.Getenv("SECTIGO_CONFIG"), "Configuration file.")
+	format := flags.String("o", formatTable, "Output format: table, json or yaml.")
+	debug := flags.Bool("debug", false, "Log the API requests and responses.")
+	flags.Usage = func() { printUsage(flags) }
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() < 2 {
+		flags.Usage()
+		return errUsage
+	}
+	group, name := flags.Arg(0), flags.Arg(1)
+	cmd, ok := commands[group][name]
+	if !ok {
+		flags.Usage()
+		return errUsage
+	}
+
+	out, err := newPrinter(*format, stdout)
+	if err != nil {
+		return err
+	}
+
+	config, err := loadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	config.Debug = *debug
+
+	client, err := sectigo.NewClientWithOptions(config, sectigo.WithUserAgent("sectigo-cli"))
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	return cmd.run(ctx, &app{client: client, out: out}, newFlagSet(stderr, group+" "+name, cmd.usage), flags.Args()[2:])
+}
+
+// printUsage prints the usage of the CLI with the list of subcommands.
+func printUsage(flags *flag.FlagSet) {
+	w := flags.Output()
+	fmt.Fprintln(w, "Usage: sectigo [flags] <group> <command> [flags] [args]")
+	fmt.Fprintln(w, "\nFlags:")
+	flags.PrintDefaults()
+	fmt.Fprintln(w, "\nCommands:")
+
+	groups := make([]string, 0, len(commands))
+	for group := range commands {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		names := make([]string, 0, len(commands[group]))
+		for name := range commands[group] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			cmd := commands[group][name]
+			fmt.Fprintf(w, "  %-20s %s\n", group+" "+name, cmd.description)
+		}
+	}
+}
+
+// newFlagSet creates the flag set of a subcommand.
+func newFlagSet(stderr io.Writer, name, usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: sectigo %s %s\n", name, usage)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseArgs parses the flags of a subcommand and checks the number of positional arguments.
+// A negative nargs requires at least one argument.
+func parseArgs(flags *flag.FlagSet, args []string, nargs int) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if (nargs >= 0 && flags.NArg() != nargs) || (nargs < 0 && flags.NArg() == 0) {
+		flags.Usage()
+		return errUsage
+	}
+	return nil
+}
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+// newTestServer starts an httptest server serving mux and points the CLI environment at it.
+func newTestServer(t *testing.T) *http.ServeMux {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(config, nil, 0o600))
+	t.Setenv("SECTIGO_CONFIG", config)
+	t.Setenv("SECTIGO_URL", server.URL)
+	t.Setenv("SECTIGO_USERNAME", "test")
+	t.Setenv("SECTIGO_CUSTOMER", "test")
+	t.Setenv("SECTIGO_PASSWORD", "test")
+	t.Setenv("SECTIGO_PASSWORD_FILE", "")
+
+	return mux
+}
+
+// runCLI runs the CLI with args, returning its standard output.
+func runCLI(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), args, &stdout, &stderr)
+	return stdout.String(), err
+}
+
+func TestSSLList(t *testing.T) {
+	mux := newTestServer(t)
+	mux.HandleFunc("/api/ssl/v1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test", r.Header.Get("login"))
+		assert.Equal(t, "Issued", r.URL.Query().Get("status"))
+		w.Header().Set("X-Total-Count", "2")
+		_ = json.NewEncoder(w).Encode([]sectigo.SSLCertificate{
+			{SSLId: 1, CommonName: "a.example.com", SerialNumber: "01"},
+			{SSLId: 2, CommonName: "b.example.com", SerialNumber: "02"},
+		})
+	})
+
+	out, err := runCLI(t, "ssl", "list", "-status", "Issued")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "ID  COMMON NAME    SERIAL NUMBER")
+	assert.Contains(t, out, "2   b.example.com  02")
+
+	out, err = runCLI(t, "-o", "json", "ssl", "list", "-status", "Issued")
+	assert.NoError(t, err)
+	var certificates []sectigo.SSLCertificate
+	assert.NoError(t, json.Unmarshal([]byte(out), &certificates))
+	assert.Len(t, certificates, 2)
+}
+
+func TestSSLGet_YAML(t *testing.T) {
+	mux := newTestServer(t)
+	mux.HandleFunc("/api/ssl/v1/123", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(sectigo.SSLDetails{SSLId: 123, CommonName: "example.com", Status: "Issued"})
+	})
+
+	out, err := runCLI(t, "-o", "yaml", "ssl", "get", "123")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "commonName: example.com\n")
+	assert.Contains(t, out, "sslId: 123\n")
+
+	out, err = runCLI(t, "ssl", "get", "123")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "FIELD")
+	assert.Regexp(t, `commonName\s+example.com`, out)
+}
+
+func TestSSLUpdate(t *testing.T) {
+	mux := newTestServer(t)
+	mux.HandleFunc("/api/ssl/v1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		var request sectigo.UpdateSSLDetailsRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, 123, request.SSLId)
+		assert.Equal(t, "renewed", request.Comments)
+		assert.Equal(t, []string{"a.example.com", "b.example.com"}, request.SubjectAlternativeNames)
+		_ = json.NewEncoder(w).Encode(sectigo.SSLDetails{SSLId: 123, Comments: "renewed"})
+	})
+
+	out, err := runCLI(t, "-o", "json", "ssl", "update", "-comments", "renewed", "-san", "a.example.com, b.example.com", "123")
+	assert.NoError(t, err)
+	assert.Contains(t, out, `"comments": "renewed"`)
+}
+
+func TestSSLRevoke(t *testing.T) {
+	mux := newTestServer(t)
+	mux.HandleFunc("/api/ssl/v1/revoke/123", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/api/ssl/v1/revoke/serial/ABCD", func(w http.ResponseWriter, r *http.Request) {
+		var request sectigo.RevokeSSLRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, sectigo.RevocationReasonSuperseded, request.ReasonCode)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	out, err := runCLI(t, "ssl", "revoke", "-reason", "compromised", "123")
+	assert.NoError(t, err)
+	assert.Equal(t, "Certificate 123 revoked\n", out)
+
+	_, err = runCLI(t, "ssl", "revoke", "-reason", "replaced", "-reason-code", "4", "-serial", "ABCD")
+	assert.NoError(t, err)
+
+	_, err = runCLI(t, "ssl", "revoke", "-reason", "replaced")
+	assert.ErrorIs(t, err, errUsage)
+
+	_, err = runCLI(t, "ssl", "revoke", "-reason", "replaced", "-reason-code", "4", "123")
+	assert.ErrorIs(t, err, errUsage)
+}
+
+func TestDomainCommands(t *testing.T) {
+	mux := newTestServer(t)
+	mux.HandleFunc("/api/domain/v1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("X-Total-Count", "1")
+			_ = json.NewEncoder(w).Encode([]sectigo.Domain{{ID: 7, Name: "example.com"}})
+		case "POST":
+			var request sectigo.DomainRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, "new.example.com", request.Name)
+			assert.True(t, request.Active)
+			assert.Equal(t, []sectigo.DelegationRequest{{OrgId: 3, CertTypes: []string{"SSL"}}}, request.Delegations)
+			w.Header().Set("Location", "/api/domain/v1/9")
+			w.WriteHeader(http.StatusCreated)
+		}
+	})
+	mux.HandleFunc("/api/domain/v1/7", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			_ = json.NewEncoder(w).Encode(sectigo.DomainDetails{ID: 7, Name: "example.com"})
+		case "PUT":
+			var request sectigo.UpdateDomainRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, "managed", *request.Description)
+			assert.False(t, *request.Active)
+			assert.Nil(t, request.Delegations)
+			w.WriteHeader(http.StatusOK)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	mux.HandleFunc("PUT /api/domain/v1/7/suspend", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/api/domain/v1/delegation", func(w http.ResponseWriter, r *http.Request) {
+		var request sectigo.DelegateDomainRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, []int{7, 8}, request.DomainIds)
+		assert.Equal(t, 3, request.OrgId)
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/api/domain/v1/7/delegation/approve", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	out, err := runCLI(t, "domain", "list")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "7   example.com")
+
+	out, err = runCLI(t, "-o", "json", "domain", "get", "7")
+	assert.NoError(t, err)
+	assert.Contains(t, out, `"name": "example.com"`)
+
+	out, err = runCLI(t, "domain", "create", "-org-id", "3", "new.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "Domain new.example.com created with ID 9\n", out)
+
+	out, err = runCLI(t, "domain", "update", "-description", "managed", "-active", "false", "7")
+	assert.NoError(t, err)
+	assert.Equal(t, "Domain 7 updated\n", out)
+
+	out, err = runCLI(t, "domain", "suspend", "7")
+	assert.NoError(t, err)
+	assert.Equal(t, "Domain 7 suspended\n", out)
+
+	_, err = runCLI(t, "domain", "delete", "7")
+	assert.NoError(t, err)
+
+	_, err = runCLI(t, "domain", "delegate", "-org-id", "3", "7", "8")
+	assert.NoError(t, err)
+
+	_, err = runCLI(t, "domain", "approve", "-org-id", "3", "7")
+	assert.NoError(t, err)
+
+	_, err = runCLI(t, "domain", "delegate", "7", "8")
+	assert.ErrorIs(t, err, errUsage)
+
+	_, err = runCLI(t, "domain", "delegate", "-org-id", "3")
+	assert.ErrorIs(t, err, errUsage)
+
+	_, err = runCLI(t, "domain", "approve", "7")
+	assert.ErrorIs(t, err, errUsage)
+
+	_, err = runCLI(t, "domain", "get", "abc")
+	assert.EqualError(t, err, `invalid ID "abc"`)
+}
+
+func TestDCVCommands(t *testing.T) {
+	mux := newTestServer(t)
+	mux.HandleFunc("/api/dcv/v1/validation/start/domain/cname", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(sectigo.StartDomainCNameValidationResponse{Host: "_abc.example.com", Point: "xyz.sectigo.com"})
+	})
+	mux.HandleFunc("/api/dcv/v1/validation/submit/domain/cname", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(sectigo.SubmitDomainCNameValidationResponse{Status: "SUBMITTED"})
+	})
+	mux.HandleFunc("/api/dcv/v2/validation/status", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(sectigo.GetDomainValidationStatusResponse{Status: "VALIDATED"})
+	})
+
+	out, err := runCLI(t, "dcv", "start", "example.com")
+	assert.NoError(t, err)
+	assert.Regexp(t, `host\s+_abc.example.com`, out)
+
+	out, err = runCLI(t, "-o", "json", "dcv", "submit", "example.com")
+	assert.NoError(t, err)
+	assert.Contains(t, out, `"status": "SUBMITTED"`)
+
+	out, err = runCLI(t, "dcv", "status", "example.com")
+	assert.NoError(t, err)
+	assert.Regexp(t, `status\s+VALIDATED`, out)
+
+	_, err = runCLI(t, "dcv", "start", "-method", "smtp", "example.com")
+	assert.Error(t, err)
+}
+
+func TestDCVCommands_Methods(t *testing.T) {
+	mux := newTestServer(t)
+	mux.HandleFunc("/api/dcv/v1/validation/start/domain/txt", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(sectigo.StartDomainTXTValidationResponse{Host: "_dnsauth.example.com", Value: "abc"})
+	})
+	mux.HandleFunc("/api/dcv/v1/validation/start/domain/https", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(sectigo.StartDomainHTTPValidationResponse{URL: "https://example.com/.well-known/pki-validation/ABC.txt"})
+	})
+	mux.HandleFunc("/api/dcv/v1/validation/submit/domain/email", func(w http.ResponseWriter, r *http.Request) {
+		var request sectigo.SubmitDomainEmailValidationRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		assert.Equal(t, "admin@example.com", request.Email)
+		_ = json.NewEncoder(w).Encode(sectigo.SubmitDomainEmailValidationResponse{OrderStatus: "SUBMITTED"})
+	})
+
+	out, err := runCLI(t, "dcv", "start", "-method", "txt", "example.com")
+	assert.NoError(t, err)
+	assert.Regexp(t, `value\s+abc`, out)
+
+	out, err = runCLI(t, "dcv", "start", "-method", "https", "example.com")
+	assert.NoError(t, err)
+	assert.Regexp(t, `url\s+https://example.com/.well-known/pki-validation/ABC.txt`, out)
+
+	out, err = runCLI(t, "dcv", "submit", "-method", "email", "-email", "admin@example.com", "example.com")
+	assert.NoError(t, err)
+	assert.Regexp(t, `orderStatus\s+SUBMITTED`, out)
+
+	_, err = runCLI(t, "dcv", "submit", "-method", "email", "example.com")
+	assert.EqualError(t, err, "-email is required by the email method")
+}
+
+func TestACMECommands(t *testing.T) {
+	mux := newTestServer(t)
+	mux.HandleFunc("/api/acme/v2/account", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "3", r.URL.Query().Get("organizationId"))
+		w.Header().Set("X-Total-Count", "1")
+		_ = json.NewEncoder(w).Encode([]sectigo.AcmeAccount{{ID: 5, Name: "acme", Status: "Valid"}})
+	})
+	mux.HandleFunc("/api/acme/v2/account/5/domain", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("X-Total-Count", "1")
+			_ = json.NewEncoder(w).Encode([]sectigo.AcmeAccountDomain{{Name: "example.com", ValidUntil: "2025-01-01"}})
+		case "POST":
+			var request sectigo.AcmeAccountDomainRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Len(t, request.Domains, 2)
+			_ = json.NewEncoder(w).Encode(map[string][]string{"notAddedDomains": {}})
+		}
+	})
+
+	out, err := runCLI(t, "acme", "accounts", "-org-id", "3")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "acme")
+
+	out, err = runCLI(t, "acme", "domains", "5")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "2025-01-01")
+
+	out, err = runCLI(t, "acme", "add-domains", "5", "a.example.com", "b.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "2 domains added to ACME account 5\n", out)
+}
+
+func TestOrgList(t *testing.T) {
+	mux := newTestServer(t)
+	mux.HandleFunc("/api/organization/v1", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]sectigo.Organization{{ID: 3, Name: "Org", Departments: []sectigo.Department{{ID: 4}}}})
+	})
+
+	out, err := runCLI(t, "org", "list")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "3   Org   1")
+}
+
+func TestRun_Errors(t *testing.T) {
+	mux := newTestServer(t)
+	mux.HandleFunc("/api/ssl/v1/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":-1400,"description":"Certificate not found"}`))
+	})
+
+	_, err := runCLI(t, "ssl", "get", "1")
+	assert.ErrorIs(t, err, sectigo.ErrNotFound)
+
+	_, err = runCLI(t, "ssl")
+	assert.ErrorIs(t, err, errUsage)
+
+	_, err = runCLI(t, "ssl", "unknown")
+	assert.ErrorIs(t, err, errUsage)
+
+	_, err = runCLI(t, "-o", "xml", "org", "list")
+	assert.Error(t, err)
+}
+
+func TestLoadConfig(t *testing.T) {
+	for _, env := range []string{"SECTIGO_URL", "SECTIGO_USERNAME", "SECTIGO_CUSTOMER", "SECTIGO_PASSWORD", "SECTIGO_PASSWORD_FILE"} {
+		t.Setenv(env, "")
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("username: user\ncustomer: customer\npassword: secret\n"), 0o600))
+
+	config, err := loadConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultURL, config.URL)
+	assert.Equal(t, "user", config.Username)
+	assert.Equal(t, "secret", config.Password)
+
+	t.Setenv("SECTIGO_USERNAME", "env-user")
+	config, err = loadConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "env-user", config.Username)
+
+	_, err = loadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+
+	assert.NoError(t, os.WriteFile(path, []byte("username: user\n"), 0o600))
+	_, err = loadConfig(path)
+	assert.Error(t, err)
+}
+
+func TestPrintList_EmptyJSON(t *testing.T) {
+	var buf bytes.Buffer
+	p, err := newPrinter(formatJSON, &buf)
+	assert.NoError(t, err)
+	assert.NoError(t, printList[sectigo.Domain](p, nil, nil))
+	assert.Equal(t, "[]\n", buf.String())
+}
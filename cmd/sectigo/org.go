@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+var orgCommands = map[string]command{
+	"list": {
+		usage:       "",
+		description: "List organizations.",
+		run:         orgList,
+	},
+}
+
+func orgList(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	if err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	organizations, err := app.client.ListOrganization(ctx)
+	if err != nil {
+		return err
+	}
+
+	return printList(app.out, *organizations, []column[sectigo.Organization]{
+		{"ID", func(o sectigo.Organization) any { return o.ID }},
+		{"NAME", func(o sectigo.Organization) any { return o.Name }},
+		{"DEPARTMENTS", func(o sectigo.Organization) any { return len(o.Departments) }},
+	})
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// printer writes the results of the subcommands in the selected format.
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+// newPrinter creates a printer writing to w in format.
+func newPrinter(format string, w io.Writer) (*printer, error) {
+	switch format {
+	case formatTable, formatJSON, formatYAML:
+		return &printer{format: format, w: w}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format %q, must be table, json or yaml", format)
+	}
+}
+
+// column is a column of a table listing items of type T.
+type column[T any] struct {
+	header string
+	value  func(T) any
+}
+
+// printList prints items as a table with columns, or encoded in the JSON or YAML format.
+func printList[T any](p *printer, items []T, columns []column[T]) error {
+	if p.format != formatTable {
+		if items == nil {
+			items = []T{}
+		}
+		return p.encode(items)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.header
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, item := range items {
+		values := make([]string, len(columns))
+		for i, col := range columns {
+			values[i] = fmt.Sprint(col.value(item))
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+// printObject prints v as a table of its fields, or encoded in the JSON or YAML format.
+func (p *printer) printObject(v any) error {
+	if p.format != formatTable {
+		return p.encode(v)
+	}
+
+	fields, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+	object, ok := fields.(map[string]any)
+	if !ok {
+		_, err := fmt.Fprintln(p.w, fields)
+		return err
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tVALUE")
+	for _, key := range keys {
+		fmt.Fprintf(tw, "%s\t%s\n", key, formatValue(object[key]))
+	}
+	return tw.Flush()
+}
+
+// printMessage prints an informational message in the table format only, so that JSON and YAML outputs
+// stay machine readable.
+func (p *printer) printMessage(format string, args ...any) error {
+	if p.format != formatTable {
+		return nil
+	}
+	_, err := fmt.Fprintf(p.w, format+"\n", args...)
+	return err
+}
+
+// encode writes v in the JSON or YAML format.
+func (p *printer) encode(v any) error {
+	if p.format == formatJSON {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	// Go through JSON so that the YAML keys are the JSON names of the API fields.
+	generic, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+	encoder := yaml.NewEncoder(p.w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(generic); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// toGeneric converts v to maps, slices and scalars through its JSON representation.
+func toGeneric(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling JSON: %w", err)
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
+	}
+	return generic, nil
+}
+
+// formatValue formats a field value in a table cell, nested values being printed as compact JSON.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case map[string]any, []any:
+		data, _ := json.Marshal(v)
+		return string(data)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
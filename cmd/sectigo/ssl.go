@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+var sslCommands = map[string]command{
+	"list": {
+		usage:       "[-cn name] [-status status] [-org-id id]",
+		description: "List SSL certificates.",
+		run:         sslList,
+	},
+	"get": {
+		usage:       "<ssl-id>",
+		description: "Show the details of an SSL certificate.",
+		run:         sslGet,
+	},
+	"update": {
+		usage:       "[flags] <ssl-id>",
+		description: "Update the details of an SSL certificate.",
+		run:         sslUpdate,
+	},
+	"revoke": {
+		usage:       "-reason reason <ssl-id> | -reason reason [-reason-code code] -serial serial",
+		description: "Revoke an SSL certificate.",
+		run:         sslRevoke,
+	},
+}
+
+func sslList(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	var params sectigo.ListSSLParams
+	flags.StringVar(&params.CommonName, "cn", "", "Filter by common name.")
+	flags.StringVar(&params.Status, "status", "", "Filter by status.")
+	flags.IntVar(&params.OrgId, "org-id", 0, "Filter by organization ID.")
+	if err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	certificates, err := app.client.ListAllSSL(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	return printList(app.out, certificates, []column[sectigo.SSLCertificate]{
+		{"ID", func(c sectigo.SSLCertificate) any { return c.SSLId }},
+		{"COMMON NAME", func(c sectigo.SSLCertificate) any { return c.CommonName }},
+		{"SERIAL NUMBER", func(c sectigo.SSLCertificate) any { return c.SerialNumber }},
+		{"SUBJECT ALTERNATIVE NAMES", func(c sectigo.SSLCertificate) any { return strings.Join(c.SubjectAlternativeNames, ",") }},
+	})
+}
+
+func sslGet(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+	sslId, err := parseID(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	details, err := app.client.GetSSLDetails(ctx, sslId)
+	if err != nil {
+		return err
+	}
+	return app.out.printObject(details)
+}
+
+func sslUpdate(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	var request sectigo.UpdateSSLDetailsRequest
+	var sans string
+	flags.StringVar(&request.CommonName, "cn", "", "New common name.")
+	flags.StringVar(&sans, "san", "", "Comma separated subject alternative names.")
+	flags.IntVar(&request.Term, "term", 0, "New term in days.")
+	flags.IntVar(&request.CertTypeId, "cert-type-id", 0, "New certificate type ID.")
+	flags.IntVar(&request.OrgId, "org-id", 0, "New organization ID.")
+	flags.StringVar(&request.Comments, "comments", "", "New comments.")
+	flags.StringVar(&request.ExternalRequester, "external-requester", "", "New external requester email addresses.")
+	flags.BoolVar(&request.SuspendNotifications, "suspend-notifications", false, "Suspend the notifications.")
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+
+	var err error
+	request.SSLId, err = parseID(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	request.SubjectAlternativeNames = splitList(sans)
+
+	details, err := app.client.UpdateSSLDetails(ctx, request)
+	if err != nil {
+		return err
+	}
+	return app.out.printObject(details)
+}
+
+func sslRevoke(ctx context.Context, app *app, flags *flag.FlagSet, args []string) error {
+	reason := flags.String("reason", "", "Revocation reason.")
+	reasonCode := flags.Int("reason-code", int(sectigo.RevocationReasonUnspecified), "RFC 5280 revocation reason code, used with -serial.")
+	serial := flags.String("serial", "", "Serial number of the certificate to revoke instead of its ID.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	reasonCodeSet := false
+	flags.Visit(func(f *flag.Flag) {
+		reasonCodeSet = reasonCodeSet || f.Name == "reason-code"
+	})
+
+	if *serial != "" {
+		if flags.NArg() != 0 {
+			flags.Usage()
+			return errUsage
+		}
+		request := sectigo.RevokeSSLRequest{ReasonCode: sectigo.RevocationReason(*reasonCode), Reason: *reason}
+		if err := app.client.RevokeSSLBySerial(ctx, *serial, request); err != nil {
+			return err
+		}
+		return app.out.printMessage("Certificate %s revoked", *serial)
+	}
+
+	// Revocation by ID does not take a reason code.
+	if flags.NArg() != 1 || reasonCodeSet {
+		flags.Usage()
+		return errUsage
+	}
+	sslId, err := parseID(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	if err := app.client.RevokeSSLById(ctx, sslId, *reason); err != nil {
+		return err
+	}
+	return app.out.printMessage("Certificate %d revoked", sslId)
+}
+
+// parseID parses a numeric ID argument.
+func parseID(arg string) (int, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ID %q", arg)
+	}
+	return id, nil
+}
+
+// splitList splits a comma separated list, returning nil for an empty string.
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -138,30 +138,24 @@ func (c *Client) ListAcmeAccount(ctx context.Context, params ListAcmeAccountPara
 	return &listAcmeAccountResponse, nil
 }
 
-// ListAllAcmeAccount sends requests to list all ACME accounts by iterating through the results using the X-Total-Count header.
-func (c *Client) ListAllAcmeAccount(ctx context.Context, params ListAcmeAccountParams) ([]AcmeAccount, error) {
-	var allAcmeAccounts []AcmeAccount
-	position := 0
-	size := 200
-
-	for {
-		params.Position = position
-		params.Size = size
-		listAcmeAccountResponse, err := c.ListAcmeAccount(ctx, params)
+// AcmeAccountPager returns a Pager over the ACME accounts matching params, using params.Size as the page size.
+func (c *Client) AcmeAccountPager(params ListAcmeAccountParams) *Pager[AcmeAccount] {
+	return NewPager(func(ctx context.Context, position, size int) ([]AcmeAccount, int, error) {
+		pageParams := params
+		pageParams.Position = position
+		pageParams.Size = size
+		listAcmeAccountResponse, err := c.ListAcmeAccount(ctx, pageParams)
 		if err != nil {
-			return nil, err
-		}
-
-		allAcmeAccounts = append(allAcmeAccounts, listAcmeAccountResponse.Accounts...)
-
-		if len(listAcmeAccountResponse.Accounts) < params.Size || position+params.Size >= listAcmeAccountResponse.TotalCount {
-			break
+			return nil, 0, err
 		}
+		return listAcmeAccountResponse.Accounts, listAcmeAccountResponse.TotalCount, nil
+	}, params.Size).WithConcurrency(c.PageConcurrency)
+}
 
-		position += params.Size
-	}
-
-	return allAcmeAccounts, nil
+// ListAllAcmeAccount sends requests to list all ACME accounts by iterating through the results using the X-Total-Count header.
+func (c *Client) ListAllAcmeAccount(ctx context.Context, params ListAcmeAccountParams) ([]AcmeAccount, error) {
+	params.Size = defaultPageSize
+	return c.AcmeAccountPager(params).Collect(ctx)
 }
 
 // ListAcmeAccountDomain sends a request to list ACME account domains via the Sectigo API.
@@ -212,30 +206,24 @@ func (c *Client) ListAcmeAccountDomain(ctx context.Context, params ListAcmeAccou
 	return &listAcmeAccountDomainResponse, nil
 }
 
-// ListAllAcmeAccountDomain sends requests to list all ACME account domains by iterating through the results using the X-Total-Count header.
-func (c *Client) ListAllAcmeAccountDomain(ctx context.Context, params ListAcmeAccountDomainParams) ([]AcmeAccountDomain, error) {
-	var allAcmeAccountDomains []AcmeAccountDomain
-	position := 0
-	size := 200
-
-	for {
-		params.Position = position
-		params.Size = size
-		listAcmeAccountDomainResponse, err := c.ListAcmeAccountDomain(ctx, params)
+// AcmeAccountDomainPager returns a Pager over the ACME account domains matching params, using params.Size as the page size.
+func (c *Client) AcmeAccountDomainPager(params ListAcmeAccountDomainParams) *Pager[AcmeAccountDomain] {
+	return NewPager(func(ctx context.Context, position, size int) ([]AcmeAccountDomain, int, error) {
+		pageParams := params
+		pageParams.Position = position
+		pageParams.Size = size
+		listAcmeAccountDomainResponse, err := c.ListAcmeAccountDomain(ctx, pageParams)
 		if err != nil {
-			return nil, err
-		}
-
-		allAcmeAccountDomains = append(allAcmeAccountDomains, listAcmeAccountDomainResponse.Domains...)
-
-		if len(listAcmeAccountDomainResponse.Domains) < params.Size || position+params.Size >= listAcmeAccountDomainResponse.TotalCount {
-			break
+			return nil, 0, err
 		}
+		return listAcmeAccountDomainResponse.Domains, listAcmeAccountDomainResponse.TotalCount, nil
+	}, params.Size).WithConcurrency(c.PageConcurrency)
+}
 
-		position += params.Size
-	}
-
-	return allAcmeAccountDomains, nil
+// ListAllAcmeAccountDomain sends requests to list all ACME account domains by iterating through the results using the X-Total-Count header.
+func (c *Client) ListAllAcmeAccountDomain(ctx context.Context, params ListAcmeAccountDomainParams) ([]AcmeAccountDomain, error) {
+	params.Size = defaultPageSize
+	return c.AcmeAccountDomainPager(params).Collect(ctx)
 }
 
 // AddAcmeAccountDomains sends a request to add domains to an ACME account via the Sectigo API.
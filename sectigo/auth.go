@@ -0,0 +1,59 @@
+package sectigo
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// AuthMode selects how the client authenticates against the Sectigo API.
+type AuthMode string
+
+// Authentication modes supported by the client
+const (
+	// AuthModePassword sends the login, customerUri and password headers. This is the default.
+	AuthModePassword AuthMode = "password"
+	// AuthModeClientCertificate authenticates with a TLS client certificate and sends only the login and customerUri headers.
+	AuthModeClientCertificate AuthMode = "certificate"
+)
+
+// loadClientCertificate loads the TLS client certificate configured for AuthModeClientCertificate,
+// either given directly, from a PKCS#12 bundle or from PEM files.
+func loadClientCertificate(config Config) (tls.Certificate, error) {
+	switch {
+	case config.ClientCertificate != nil:
+		return *config.ClientCertificate, nil
+	case config.ClientPKCS12File != "":
+		data, err := os.ReadFile(config.ClientPKCS12File)
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("error reading PKCS#12 file: %w", err)
+		}
+		key, leaf, caCerts, err := pkcs12.DecodeChain(data, config.ClientPKCS12Password)
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("error decoding PKCS#12 file: %w", err)
+		}
+		certificate := tls.Certificate{
+			Certificate: [][]byte{leaf.Raw},
+			PrivateKey:  key,
+			Leaf:        leaf,
+		}
+		for _, caCert := range caCerts {
+			certificate.Certificate = append(certificate.Certificate, caCert.Raw)
+		}
+		return certificate, nil
+	case config.ClientCertFile != "":
+		keyFile := config.ClientKeyFile
+		if keyFile == "" {
+			keyFile = config.ClientCertFile
+		}
+		certificate, err := tls.LoadX509KeyPair(config.ClientCertFile, keyFile)
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		return certificate, nil
+	default:
+		return tls.Certificate{}, fmt.Errorf("client certificate authentication requires ClientCertificate, ClientPKCS12File or ClientCertFile")
+	}
+}
@@ -0,0 +1,185 @@
+package sectigo
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// generateTestClientCertificate returns a self-signed client certificate and its private key.
+func generateTestClientCertificate(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "api-admin"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	return certificate, key
+}
+
+// newMTLSServer starts a TLS server requiring the given client certificate.
+func newMTLSServer(t *testing.T, clientCertificate *x509.Certificate) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "api-admin", r.TLS.PeerCertificates[0].Subject.CommonName)
+		assert.Equal(t, "test", r.Header.Get("login"))
+		assert.Equal(t, "test", r.Header.Get("customerUri"))
+		assert.Empty(t, r.Header.Values("password"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[]`)) //nolint:errcheck
+	}))
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCertificate)
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+
+	return server
+}
+
+func serverTLSConfig(server *httptest.Server) *tls.Config {
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+	return &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+}
+
+func TestClientCertificateAuth_PEM(t *testing.T) {
+	certificate, key := generateTestClientCertificate(t)
+	server := newMTLSServer(t, certificate)
+	defer server.Close()
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client.key")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw}), 0o600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	client, err := NewClientWithOptions(Config{
+		URL:            server.URL,
+		Username:       "test",
+		Customer:       "test",
+		Password:       "ignored",
+		AuthMode:       AuthModeClientCertificate,
+		ClientCertFile: certFile,
+		ClientKeyFile:  keyFile,
+	}, WithTLSConfig(serverTLSConfig(server)))
+	assert.NoError(t, err)
+
+	_, err = client.ListOrganization(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestClientCertificateAuth_PKCS12(t *testing.T) {
+	certificate, key := generateTestClientCertificate(t)
+	server := newMTLSServer(t, certificate)
+	defer server.Close()
+
+	data, err := pkcs12.Modern.Encode(key, certificate, nil, "secret")
+	assert.NoError(t, err)
+
+	pkcs12File := filepath.Join(t.TempDir(), "client.p12")
+	assert.NoError(t, os.WriteFile(pkcs12File, data, 0o600))
+
+	client, err := NewClientWithOptions(Config{
+		URL:                  server.URL,
+		Username:             "test",
+		Customer:             "test",
+		AuthMode:             AuthModeClientCertificate,
+		ClientPKCS12File:     pkcs12File,
+		ClientPKCS12Password: "secret",
+	}, WithTLSConfig(serverTLSConfig(server)))
+	assert.NoError(t, err)
+
+	_, err = client.ListOrganization(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestClientCertificateAuth_Errors(t *testing.T) {
+	_, err := NewClientWithOptions(Config{URL: "https://cert-manager.com", AuthMode: AuthModeClientCertificate})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "requires ClientCertificate, ClientPKCS12File or ClientCertFile")
+
+	_, err = NewClientWithOptions(Config{
+		URL:              "https://cert-manager.com",
+		AuthMode:         AuthModeClientCertificate,
+		ClientPKCS12File: filepath.Join(t.TempDir(), "missing.p12"),
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error reading PKCS#12 file")
+
+	_, err = NewClientWithOptions(Config{URL: "https://cert-manager.com", AuthMode: "token"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported auth mode "token"`)
+}
+
+func TestNewClient_ConfigurationErrorReturnedByRequests(t *testing.T) {
+	client := NewClient(Config{URL: "https://cert-manager.com", AuthMode: AuthModeClientCertificate})
+
+	_, err := client.ListOrganization(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "requires ClientCertificate, ClientPKCS12File or ClientCertFile")
+}
+
+func TestNewClient_ConfigurationErrorLogged(t *testing.T) {
+	var logs bytes.Buffer
+	NewClient(Config{
+		URL:      "https://cert-manager.com",
+		AuthMode: AuthModeClientCertificate,
+		Logger:   slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+
+	assert.Contains(t, logs.String(), "level=ERROR")
+	assert.Contains(t, logs.String(), "requires ClientCertificate, ClientPKCS12File or ClientCertFile")
+}
+
+func TestNewClient_ConfigurationErrorLoggedByDefault(t *testing.T) {
+	var logs bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer slog.SetDefault(defaultLogger)
+
+	NewClient(Config{
+		URL:      "https://cert-manager.com",
+		AuthMode: AuthModeClientCertificate,
+	})
+
+	assert.Contains(t, logs.String(), "level=ERROR")
+	assert.Contains(t, logs.String(), "requires ClientCertificate, ClientPKCS12File or ClientCertFile")
+}
@@ -3,12 +3,22 @@ package sectigo
 import (
 	"bytes"
 	"context"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // ListSSLParams represents the parameters for listing SSL certificates.
@@ -59,6 +69,75 @@ type RevokeSSLParams struct {
 	Reason string `json:"reason"`
 }
 
+// RevocationReason represents an RFC 5280 CRL reason code
+type RevocationReason int
+
+// RFC 5280 revocation reason codes
+const (
+	RevocationReasonUnspecified          RevocationReason = 0
+	RevocationReasonKeyCompromise        RevocationReason = 1
+	RevocationReasonCACompromise         RevocationReason = 2
+	RevocationReasonAffiliationChanged   RevocationReason = 3
+	RevocationReasonSuperseded           RevocationReason = 4
+	RevocationReasonCessationOfOperation RevocationReason = 5
+	RevocationReasonCertificateHold      RevocationReason = 6
+	RevocationReasonRemoveFromCRL        RevocationReason = 8
+	RevocationReasonPrivilegeWithdrawn   RevocationReason = 9
+	RevocationReasonAACompromise         RevocationReason = 10
+)
+
+// String returns the RFC 5280 name of the reason code
+func (r RevocationReason) String() string {
+	switch r {
+	case RevocationReasonUnspecified:
+		return "unspecified"
+	case RevocationReasonKeyCompromise:
+		return "keyCompromise"
+	case RevocationReasonCACompromise:
+		return "cACompromise"
+	case RevocationReasonAffiliationChanged:
+		return "affiliationChanged"
+	case RevocationReasonSuperseded:
+		return "superseded"
+	case RevocationReasonCessationOfOperation:
+		return "cessationOfOperation"
+	case RevocationReasonCertificateHold:
+		return "certificateHold"
+	case RevocationReasonRemoveFromCRL:
+		return "removeFromCRL"
+	case RevocationReasonPrivilegeWithdrawn:
+		return "privilegeWithdrawn"
+	case RevocationReasonAACompromise:
+		return "aACompromise"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(r))
+	}
+}
+
+// validRevocationReasons lists the reason codes accepted by the Sectigo API when revoking a certificate
+var validRevocationReasons = map[RevocationReason]bool{
+	RevocationReasonUnspecified:          true,
+	RevocationReasonKeyCompromise:        true,
+	RevocationReasonAffiliationChanged:   true,
+	RevocationReasonSuperseded:           true,
+	RevocationReasonCessationOfOperation: true,
+}
+
+// RevokeSSLRequest represents the request body for revoking an SSL certificate with a reason code
+type RevokeSSLRequest struct {
+	ReasonCode RevocationReason `json:"reasonCode"`
+	Reason     string           `json:"reason"`
+}
+
+// MarkSSLAsRevokedRequest represents the request body for marking an SSL certificate revoked outside SCM
+type MarkSSLAsRevokedRequest struct {
+	CertId       int              `json:"certId,omitempty"`
+	SerialNumber string           `json:"serialNumber,omitempty"`
+	Issuer       string           `json:"issuer,omitempty"`
+	RevokeDate   string           `json:"revokeDate,omitempty"`
+	ReasonCode   RevocationReason `json:"reasonCode"`
+}
+
 // SSLDetails represents the detailed information about an SSL certificate
 type SSLDetails struct {
 	CommonName              string             `json:"commonName"`
@@ -87,7 +166,7 @@ type SSLDetails struct {
 	Expires                 string             `json:"expires"`
 	Replaced                string             `json:"replaced"`
 	Revoked                 string             `json:"revoked"`
-	ReasonCode              int                `json:"reasonCode"`
+	ReasonCode              RevocationReason   `json:"reasonCode"`
 	Renewed                 bool               `json:"renewed"`
 	RenewedDate             string             `json:"renewedDate"`
 	SerialNumber            string             `json:"serialNumber"`
@@ -115,6 +194,11 @@ type CertType struct {
 	KeyTypes            KeyTypes `json:"keyTypes"`
 }
 
+// SupportsTerm reports whether the term, in days, is available for the Certificate Profile
+func (t CertType) SupportsTerm(term int) bool {
+	return slices.Contains(t.Terms, term)
+}
+
 // KeyTypes represents key types available for the Certificate Profile
 type KeyTypes struct {
 	RSA []string `json:"rsa"`
@@ -127,6 +211,20 @@ type CustomField struct {
 	Value string `json:"value"`
 }
 
+// CustomFieldDefinition represents the definition of an SSL custom field
+type CustomFieldDefinition struct {
+	Id        int              `json:"id"`
+	Name      string           `json:"name"`
+	Mandatory bool             `json:"mandatory"`
+	Input     CustomFieldInput `json:"input"`
+}
+
+// CustomFieldInput represents the input type of a custom field and its allowed values
+type CustomFieldInput struct {
+	Type    string   `json:"type"`
+	Options []string `json:"options"`
+}
+
 // CertificateDetails represents certificate details
 type CertificateDetails struct {
 	Issuer          string `json:"issuer"`
@@ -189,6 +287,96 @@ type UpdateSSLDetailsRequest struct {
 	ApproverAdminId         int               `json:"approverAdminId,omitempty"`
 }
 
+// EnrollSSLRequest represents the request body for enrolling a new SSL certificate
+type EnrollSSLRequest struct {
+	OrgId             int           `json:"orgId"`
+	CertType          int           `json:"certType"`
+	Term              int           `json:"term"`
+	CSR               string        `json:"csr"`
+	SubjAltNames      string        `json:"subjAltNames,omitempty"`
+	NumberServers     int           `json:"numberServers,omitempty"`
+	ServerType        int           `json:"serverType,omitempty"`
+	Comments          string        `json:"comments,omitempty"`
+	ExternalRequester string        `json:"externalRequester,omitempty"`
+	CustomFields      []CustomField `json:"customFields,omitempty"`
+	AutoRenew         bool          `json:"autoRenew,omitempty"`
+	AutoRenewDays     int           `json:"autoRenewDays,omitempty"`
+}
+
+// EnrollSSLResponse represents the response structure for enrolling a new SSL certificate
+type EnrollSSLResponse struct {
+	SSLId   int    `json:"sslId"`
+	RenewId string `json:"renewId"`
+}
+
+// CollectFormat represents the format in which an issued certificate is downloaded
+type CollectFormat string
+
+// Formats supported by the collect endpoint
+const (
+	CollectFormatX509    CollectFormat = "x509"    // X509, Base64 encoded, with chain
+	CollectFormatX509CO  CollectFormat = "x509CO"  // X509 certificate only, Base64 encoded
+	CollectFormatX509IO  CollectFormat = "x509IO"  // X509 intermediates and root only, Base64 encoded
+	CollectFormatX509IOR CollectFormat = "x509IOR" // X509 intermediates and root only, reverse order
+	CollectFormatBase64  CollectFormat = "base64"  // PKCS#7, Base64 encoded
+	CollectFormatBin     CollectFormat = "bin"     // PKCS#7, binary encoded
+	CollectFormatPEM     CollectFormat = "pem"     // Certificate with chain, PEM encoded
+	CollectFormatPEMCO   CollectFormat = "pemco"   // Certificate only, PEM encoded
+	CollectFormatPEMIA   CollectFormat = "pemia"   // Certificate with issuer after, PEM encoded
+)
+
+// ErrCertificateNotIssued is returned by CollectSSL when the certificate has not been issued yet
+var ErrCertificateNotIssued = errors.New("certificate is not yet issued")
+
+// notIssuedErrorCodes lists the Sectigo error codes returned when collecting a pending certificate
+var notIssuedErrorCodes = map[int]bool{
+	-183:  true,
+	-1400: true,
+}
+
+// CollectSSLResponse represents a downloaded SSL certificate
+type CollectSSLResponse struct {
+	Format       CollectFormat
+	Data         []byte
+	Certificates []*x509.Certificate
+}
+
+// RenewSSLRequest represents the optional request body for renewing an SSL certificate
+type RenewSSLRequest struct {
+	CSR string `json:"csr,omitempty"`
+}
+
+// RenewSSLResponse represents the response structure for renewing an SSL certificate
+type RenewSSLResponse struct {
+	SSLId int `json:"sslId"`
+}
+
+// ReplaceSSLRequest represents the request body for replacing an SSL certificate
+type ReplaceSSLRequest struct {
+	CSR                     string `json:"csr,omitempty"`
+	Reason                  string `json:"reason"`
+	CommonName              string `json:"commonName,omitempty"`
+	SubjectAlternativeNames string `json:"subjectAlternativeNames,omitempty"`
+}
+
+// ErrCertificateRejected is returned by EnrollAndCollect when the certificate request is rejected or declined
+var ErrCertificateRejected = errors.New("certificate request was rejected")
+
+// EnrollAndCollectOptions represents the polling options used by EnrollAndCollect
+type EnrollAndCollectOptions struct {
+	Format          CollectFormat
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+	BackoffFactor   float64
+}
+
+// EnrollAndCollectResponse represents the result of an enrollment followed by the collection of the certificate
+type EnrollAndCollectResponse struct {
+	SSLId   int
+	RenewId string
+	*CollectSSLResponse
+}
+
 // ListSSL sends a request to list SSL certificates via the Sectigo API.
 func (c *Client) ListSSL(ctx context.Context, params ListSSLParams) (*ListSSLResponse, error) {
 	baseURL, err := url.Parse(fmt.Sprintf("%s/api/ssl/v1", c.BaseURL))
@@ -292,30 +480,24 @@ func (c *Client) ListSSL(ctx context.Context, params ListSSLParams) (*ListSSLRes
 	return &listSSLResponse, nil
 }
 
-// ListAllSSL sends requests to list all SSL certificates by iterating through the results using the X-Total-Count header.
-func (c *Client) ListAllSSL(ctx context.Context, params ListSSLParams) ([]SSLCertificate, error) {
-	var allSSLCertificates []SSLCertificate
-	position := 0
-	size := 200
-
-	for {
-		params.Position = position
-		params.Size = size
-		listSSLResponse, err := c.ListSSL(ctx, params)
+// SSLPager returns a Pager over the SSL certificates matching params, using params.Size as the page size.
+func (c *Client) SSLPager(params ListSSLParams) *Pager[SSLCertificate] {
+	return NewPager(func(ctx context.Context, position, size int) ([]SSLCertificate, int, error) {
+		pageParams := params
+		pageParams.Position = position
+		pageParams.Size = size
+		listSSLResponse, err := c.ListSSL(ctx, pageParams)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
+		return listSSLResponse.SSLCertificates, listSSLResponse.TotalCount, nil
+	}, params.Size).WithConcurrency(c.PageConcurrency)
+}
 
-		allSSLCertificates = append(allSSLCertificates, listSSLResponse.SSLCertificates...)
-
-		if len(listSSLResponse.SSLCertificates) < params.Size || position+params.Size >= listSSLResponse.TotalCount {
-			break
-		}
-
-		position += params.Size
-	}
-
-	return allSSLCertificates, nil
+// ListAllSSL sends requests to list all SSL certificates by iterating through the results using the X-Total-Count header.
+func (c *Client) ListAllSSL(ctx context.Context, params ListSSLParams) ([]SSLCertificate, error) {
+	params.Size = defaultPageSize
+	return c.SSLPager(params).Collect(ctx)
 }
 
 // RevokeSSLById sends a request to revoke an SSL certificate by ID via the Sectigo API.
@@ -341,6 +523,148 @@ func (c *Client) RevokeSSLById(ctx context.Context, sslId int, reason string) er
 	return err
 }
 
+// validateRevokeSSLRequest validates the request parameters
+func validateRevokeSSLRequest(request RevokeSSLRequest) error {
+	if !validRevocationReasons[request.ReasonCode] {
+		return fmt.Errorf("reasonCode %s is not allowed, must be one of 0, 1, 3, 4 or 5", request.ReasonCode)
+	}
+
+	if request.Reason == "" || len(request.Reason) > 512 {
+		return fmt.Errorf("reason must be between 1 and 512 characters")
+	}
+
+	return nil
+}
+
+// revokeSSL posts a revocation request with a reason code to the given revoke endpoint.
+func (c *Client) revokeSSL(ctx context.Context, url string, request RevokeSSLRequest) error {
+	if err := validateRevokeSSLRequest(request); err != nil {
+		return err
+	}
+
+	reqBodyJSON, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("error marshalling request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBodyJSON))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, _, err = c.sendRequest(ctx, req, http.StatusNoContent)
+	return err
+}
+
+// RevokeSSLBySerial sends a request to revoke an SSL certificate by serial number via the Sectigo API.
+func (c *Client) RevokeSSLBySerial(ctx context.Context, serialNumber string, request RevokeSSLRequest) error {
+	if serialNumber == "" {
+		return fmt.Errorf("serialNumber must not be empty")
+	}
+
+	url := fmt.Sprintf("%s/api/ssl/v1/revoke/serial/%s", c.BaseURL, url.PathEscape(serialNumber))
+	return c.revokeSSL(ctx, url, request)
+}
+
+// RevokeSSLByCert resolves the sslId of a certificate through ListSSL, by serial number then by SHA-1 fingerprint,
+// and sends a request to revoke it via the Sectigo API.
+func (c *Client) RevokeSSLByCert(ctx context.Context, cert *x509.Certificate, request RevokeSSLRequest) error {
+	if cert == nil {
+		return fmt.Errorf("certificate must not be nil")
+	}
+	if err := validateRevokeSSLRequest(request); err != nil {
+		return err
+	}
+
+	sslId, err := c.findSSLIdByCert(ctx, cert)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/ssl/v1/revoke/%d", c.BaseURL, sslId)
+	return c.revokeSSL(ctx, url, request)
+}
+
+// findSSLIdByCert looks up the sslId of a certificate using the serialNumber and sha1Hash filters of ListSSL.
+// Serial numbers are only unique per issuer, so an ambiguous serial number falls back to the SHA-1 fingerprint.
+// The returned error matches ErrNotFound when neither lookup finds the certificate.
+func (c *Client) findSSLIdByCert(ctx context.Context, cert *x509.Certificate) (int, error) {
+	serialNumber := certSerialNumberHex(cert)
+	sha1Hash := sha1.Sum(cert.Raw)
+	fingerprint := strings.ToUpper(hex.EncodeToString(sha1Hash[:]))
+	ambiguousSerialNumber := false
+
+	for _, lookup := range []struct {
+		filter string
+		params ListSSLParams
+	}{
+		{"serial number " + serialNumber, ListSSLParams{Size: 2, SerialNumber: serialNumber}},
+		{"SHA-1 fingerprint " + fingerprint, ListSSLParams{Size: 2, Sha1Hash: fingerprint}},
+	} {
+		listSSLResponse, err := c.ListSSL(ctx, lookup.params)
+		if err != nil {
+			return 0, err
+		}
+
+		switch len(listSSLResponse.SSLCertificates) {
+		case 0:
+			continue
+		case 1:
+			return listSSLResponse.SSLCertificates[0].SSLId, nil
+		default:
+			if lookup.params.SerialNumber != "" {
+				ambiguousSerialNumber = true
+				continue
+			}
+			return 0, fmt.Errorf("multiple certificates match %s", lookup.filter)
+		}
+	}
+
+	if ambiguousSerialNumber {
+		return 0, fmt.Errorf("multiple certificates match serial number %s", serialNumber)
+	}
+	return 0, fmt.Errorf("%w: no certificate found with serial number %s or SHA-1 fingerprint %s", ErrNotFound, serialNumber, fingerprint)
+}
+
+// certSerialNumberHex returns the serial number of cert in upper case hexadecimal, left-padded to an even
+// length like the serial numbers of SCM.
+func certSerialNumberHex(cert *x509.Certificate) string {
+	serialNumber := strings.ToUpper(cert.SerialNumber.Text(16))
+	if len(serialNumber)%2 == 1 {
+		serialNumber = "0" + serialNumber
+	}
+	return serialNumber
+}
+
+// MarkSSLAsRevoked sends a request to mark an SSL certificate revoked outside SCM as revoked via the Sectigo API.
+func (c *Client) MarkSSLAsRevoked(ctx context.Context, request MarkSSLAsRevokedRequest) error {
+	if request.CertId < 1 && request.SerialNumber == "" {
+		return fmt.Errorf("certId or serialNumber must be provided")
+	}
+	if request.SerialNumber != "" && request.Issuer == "" {
+		return fmt.Errorf("issuer must be provided with serialNumber")
+	}
+	if !validRevocationReasons[request.ReasonCode] {
+		return fmt.Errorf("reasonCode %s is not allowed, must be one of 0, 1, 3, 4 or 5", request.ReasonCode)
+	}
+
+	url := fmt.Sprintf("%s/api/ssl/v1/revoke/manual", c.BaseURL)
+	reqBodyJSON, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("error marshalling request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBodyJSON))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, _, err = c.sendRequest(ctx, req, http.StatusNoContent)
+	return err
+}
+
 // GetSSLDetails retrieves detailed information about an SSL certificate
 func (c *Client) GetSSLDetails(ctx context.Context, sslId int) (*SSLDetails, error) {
 	baseURL, err := url.Parse(fmt.Sprintf("%s/api/ssl/v1/%d", c.BaseURL, sslId))
@@ -368,6 +692,507 @@ func (c *Client) GetSSLDetails(ctx context.Context, sslId int) (*SSLDetails, err
 	return &sslDetails, nil
 }
 
+// RenewSSLById sends a request to renew an SSL certificate by ID via the Sectigo API.
+// An optional fresh CSR can be provided in the request, otherwise the original key is reused.
+func (c *Client) RenewSSLById(ctx context.Context, sslId int, request RenewSSLRequest) (*RenewSSLResponse, error) {
+	if sslId < 1 {
+		return nil, fmt.Errorf("sslId must be at least 1")
+	}
+	if request.CSR != "" {
+		if err := validateCSR(request.CSR); err != nil {
+			return nil, err
+		}
+	}
+
+	baseURL, err := url.Parse(fmt.Sprintf("%s/api/ssl/v1/renewById/%d", c.BaseURL, sslId))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing base URL: %v", err)
+	}
+
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling request body: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL.String(), bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json;charset=UTF-8")
+
+	_, body, err := c.sendRequest(ctx, req, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var renewResponse RenewSSLResponse
+	err = json.Unmarshal(body, &renewResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling response: %v", err)
+	}
+
+	return &renewResponse, nil
+}
+
+// RenewSSLByRenewId sends a request to renew an SSL certificate by the renewId returned at enrollment via the Sectigo API.
+func (c *Client) RenewSSLByRenewId(ctx context.Context, renewId string) (*RenewSSLResponse, error) {
+	if renewId == "" {
+		return nil, fmt.Errorf("renewId must not be empty")
+	}
+
+	baseURL, err := url.Parse(fmt.Sprintf("%s/api/ssl/v1/renew/%s", c.BaseURL, url.PathEscape(renewId)))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing base URL: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	_, body, err := c.sendRequest(ctx, req, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var renewResponse RenewSSLResponse
+	err = json.Unmarshal(body, &renewResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling response: %v", err)
+	}
+
+	return &renewResponse, nil
+}
+
+// ReplaceSSL sends a request to replace an SSL certificate with a new CSR and/or SANs via the Sectigo API.
+func (c *Client) ReplaceSSL(ctx context.Context, sslId int, request ReplaceSSLRequest) error {
+	if sslId < 1 {
+		return fmt.Errorf("sslId must be at least 1")
+	}
+	if request.Reason == "" || len(request.Reason) > 512 {
+		return fmt.Errorf("reason must be between 1 and 512 characters")
+	}
+	if request.CSR == "" && request.SubjectAlternativeNames == "" {
+		return fmt.Errorf("csr or subjectAlternativeNames must be provided")
+	}
+	if request.CSR != "" {
+		if err := validateCSR(request.CSR); err != nil {
+			return err
+		}
+	}
+
+	url := fmt.Sprintf("%s/api/ssl/v1/replace/%d", c.BaseURL, sslId)
+	reqBodyJSON, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("error marshalling request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBodyJSON))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, _, err = c.sendRequest(ctx, req, http.StatusNoContent)
+	return err
+}
+
+// ApproveSSL sends a request to approve a pending SSL certificate request via the Sectigo API.
+func (c *Client) ApproveSSL(ctx context.Context, sslId int, message string) error {
+	return c.sendSSLApproval(ctx, "approve", sslId, message)
+}
+
+// DeclineSSL sends a request to decline a pending SSL certificate request via the Sectigo API.
+func (c *Client) DeclineSSL(ctx context.Context, sslId int, message string) error {
+	return c.sendSSLApproval(ctx, "decline", sslId, message)
+}
+
+// sendSSLApproval posts an approval decision with an optional message for an SSL certificate request.
+func (c *Client) sendSSLApproval(ctx context.Context, action string, sslId int, message string) error {
+	if sslId < 1 {
+		return fmt.Errorf("sslId must be at least 1")
+	}
+	if len(message) > 512 {
+		return fmt.Errorf("message maximum length is 512 characters or can be empty")
+	}
+
+	url := fmt.Sprintf("%s/api/ssl/v1/%s/%d", c.BaseURL, action, sslId)
+	reqBody := map[string]string{"message": message}
+	reqBodyJSON, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("error marshalling request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBodyJSON))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, _, err = c.sendRequest(ctx, req, http.StatusNoContent)
+	return err
+}
+
+// CollectSSL downloads an issued SSL certificate in the given format and parses the certificates it contains.
+// It returns ErrCertificateNotIssued while the certificate is still being processed, so callers can poll.
+func (c *Client) CollectSSL(ctx context.Context, sslId int, format CollectFormat) (*CollectSSLResponse, error) {
+	baseURL, err := url.Parse(fmt.Sprintf("%s/api/ssl/v1/collect/%d/%s", c.BaseURL, sslId, url.PathEscape(string(format))))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing base URL: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	_, body, err := c.sendRequest(ctx, req, http.StatusOK)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest && notIssuedErrorCodes[apiErr.Code] {
+			return nil, fmt.Errorf("%w: %w", ErrCertificateNotIssued, err)
+		}
+		return nil, err
+	}
+
+	certificates, err := parseCollectedCertificates(body, format)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing certificates: %v", err)
+	}
+
+	return &CollectSSLResponse{
+		Format:       format,
+		Data:         body,
+		Certificates: certificates,
+	}, nil
+}
+
+// WaitForSSLIssued polls the details of sslId until the certificate is issued. It returns an error matching
+// ErrCertificateRejected when the request is rejected or declined, and a *WaitTimeoutError when
+// options.Timeout, options.MaxAttempts or ctx run out first.
+func (c *Client) WaitForSSLIssued(ctx context.Context, sslId int, options WaitOptions) (*SSLDetails, error) {
+	return Wait(ctx, func(ctx context.Context) (*SSLDetails, string, bool, error) {
+		sslDetails, err := c.GetSSLDetails(ctx, sslId)
+		if err != nil {
+			return nil, "", false, err
+		}
+		if err := checkSSLRejected(sslId, sslDetails.Status); err != nil {
+			return nil, sslDetails.Status, false, err
+		}
+		return sslDetails, sslDetails.Status, sslDetails.Status == "Issued", nil
+	}, options)
+}
+
+// checkSSLRejected returns an error matching ErrCertificateRejected when status is a rejection status.
+func checkSSLRejected(sslId int, status string) error {
+	switch status {
+	case "Rejected", "Declined":
+		return fmt.Errorf("%w: sslId %d has status %s", ErrCertificateRejected, sslId, status)
+	}
+	return nil
+}
+
+// EnrollAndCollect enrolls a new SSL certificate, polls its status until it is issued and collects it.
+// Polling backs off exponentially from PollInterval up to MaxPollInterval and stops when ctx is done.
+// Once the certificate is enrolled, errors are returned along with a response holding only SSLId and RenewId,
+// so that the order can be resumed with WaitForSSLIssued and CollectSSL instead of enrolling it again.
+func (c *Client) EnrollAndCollect(ctx context.Context, request EnrollSSLRequest, options EnrollAndCollectOptions) (*EnrollAndCollectResponse, error) {
+	if options.Format == "" {
+		options.Format = CollectFormatX509
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = 10 * time.Second
+	}
+	if options.MaxPollInterval <= 0 {
+		options.MaxPollInterval = 5 * time.Minute
+	}
+	options.MaxPollInterval = max(options.MaxPollInterval, options.PollInterval)
+	if options.BackoffFactor < 1 {
+		options.BackoffFactor = 2
+	}
+
+	enrollResponse, err := c.EnrollSSL(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	collectResponse, err := Wait(ctx, func(ctx context.Context) (*CollectSSLResponse, string, bool, error) {
+		sslDetails, err := c.GetSSLDetails(ctx, enrollResponse.SSLId)
+		if err != nil {
+			return nil, "", false, err
+		}
+		if err := checkSSLRejected(enrollResponse.SSLId, sslDetails.Status); err != nil {
+			return nil, sslDetails.Status, false, err
+		}
+		if sslDetails.Status != "Issued" {
+			return nil, sslDetails.Status, false, nil
+		}
+
+		// The certificate may not be ready for collection right after being issued.
+		collectResponse, err := c.CollectSSL(ctx, enrollResponse.SSLId, options.Format)
+		if errors.Is(err, ErrCertificateNotIssued) {
+			return nil, sslDetails.Status, false, nil
+		}
+		return collectResponse, sslDetails.Status, err == nil, err
+	}, WaitOptions{
+		Backoff: ExponentialBackoff{Initial: options.PollInterval, Max: options.MaxPollInterval, Factor: options.BackoffFactor},
+	})
+	response := &EnrollAndCollectResponse{
+		SSLId:   enrollResponse.SSLId,
+		RenewId: enrollResponse.RenewId,
+	}
+	if errors.Is(err, ErrWaitTimeout) {
+		return response, fmt.Errorf("waiting for sslId %d to be issued: %w", enrollResponse.SSLId, err)
+	}
+	if err != nil {
+		return response, err
+	}
+
+	response.CollectSSLResponse = collectResponse
+	return response, nil
+}
+
+// parseCollectedCertificates decodes the certificates contained in a collect response
+func parseCollectedCertificates(data []byte, format CollectFormat) ([]*x509.Certificate, error) {
+	switch format {
+	case CollectFormatBin:
+		return parsePKCS7Certificates(data)
+	case CollectFormatBase64:
+		if block, _ := pem.Decode(data); block != nil {
+			return parsePKCS7Certificates(block.Bytes)
+		}
+		der, err := base64.StdEncoding.DecodeString(string(bytes.Join(bytes.Fields(data), nil)))
+		if err != nil {
+			return nil, fmt.Errorf("error decoding base64: %w", err)
+		}
+		return parsePKCS7Certificates(der)
+	default:
+		return parsePEMCertificates(data)
+	}
+}
+
+// parsePEMCertificates decodes all CERTIFICATE blocks of a PEM bundle
+func parsePEMCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type == "PKCS7" {
+			pkcs7Certificates, err := parsePKCS7Certificates(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			certificates = append(certificates, pkcs7Certificates...)
+			continue
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no certificate found")
+	}
+
+	return certificates, nil
+}
+
+// pkcs7ContentInfo is the outer ASN.1 structure of a PKCS#7 message
+type pkcs7ContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+// pkcs7SignedData is the degenerate PKCS#7 SignedData structure used to transport certificate chains
+type pkcs7SignedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	ContentInfo      asn1.RawValue
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      asn1.RawValue
+}
+
+// parsePKCS7Certificates extracts the certificates of a DER encoded PKCS#7 SignedData message
+func parsePKCS7Certificates(der []byte) ([]*x509.Certificate, error) {
+	var contentInfo pkcs7ContentInfo
+	if _, err := asn1.Unmarshal(der, &contentInfo); err != nil {
+		return nil, fmt.Errorf("error decoding PKCS#7 content info: %w", err)
+	}
+
+	var signedData pkcs7SignedData
+	if _, err := asn1.Unmarshal(contentInfo.Content.Bytes, &signedData); err != nil {
+		return nil, fmt.Errorf("error decoding PKCS#7 signed data: %w", err)
+	}
+
+	if len(signedData.Certificates.Bytes) == 0 {
+		return nil, fmt.Errorf("no certificate found")
+	}
+
+	return x509.ParseCertificates(signedData.Certificates.Bytes)
+}
+
+// ListSSLTypes retrieves the SSL certificate types available for an organization.
+// When orgId is 0, the types available to the customer are returned.
+func (c *Client) ListSSLTypes(ctx context.Context, orgId int) ([]CertType, error) {
+	baseURL, err := url.Parse(fmt.Sprintf("%s/api/ssl/v1/types", c.BaseURL))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing base URL: %v", err)
+	}
+
+	if orgId > 0 {
+		queryParams := url.Values{}
+		queryParams.Add("organizationId", fmt.Sprintf("%d", orgId))
+		baseURL.RawQuery = queryParams.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	_, body, err := c.sendRequest(ctx, req, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var certTypes []CertType
+	err = json.Unmarshal(body, &certTypes)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling response: %v", err)
+	}
+
+	return certTypes, nil
+}
+
+// ListSSLCustomFields retrieves the definitions of the SSL certificate custom fields.
+func (c *Client) ListSSLCustomFields(ctx context.Context) ([]CustomFieldDefinition, error) {
+	baseURL, err := url.Parse(fmt.Sprintf("%s/api/ssl/v1/customFields", c.BaseURL))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing base URL: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	_, body, err := c.sendRequest(ctx, req, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var customFields []CustomFieldDefinition
+	err = json.Unmarshal(body, &customFields)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling response: %v", err)
+	}
+
+	return customFields, nil
+}
+
+// validateCSR checks that a CSR only contains characters accepted by the Sectigo API
+func validateCSR(csr string) error {
+	csrRegex := regexp.MustCompile(`^[a-zA-Z0-9-+=\/\s]+$`)
+	if !csrRegex.MatchString(csr) {
+		return fmt.Errorf("csr must match the regular expression [a-zA-Z0-9-+=\\/\\s]+")
+	}
+	if len(csr) > 32767 {
+		return fmt.Errorf("csr size must be between 1 and 32767 inclusive")
+	}
+
+	return nil
+}
+
+// validateEnrollSSLRequest validates the request parameters
+func validateEnrollSSLRequest(request EnrollSSLRequest) error {
+	if request.OrgId < 1 {
+		return fmt.Errorf("orgId must be at least 1")
+	}
+
+	if request.CertType < 1 {
+		return fmt.Errorf("certType must be at least 1")
+	}
+
+	if request.Term < 1 {
+		return fmt.Errorf("term must be at least 1")
+	}
+
+	if request.CSR == "" {
+		return fmt.Errorf("csr must not be empty")
+	}
+	if err := validateCSR(request.CSR); err != nil {
+		return err
+	}
+
+	if len(request.Comments) > 1024 {
+		return fmt.Errorf("comments maximum length is 1024 characters or can be empty")
+	}
+
+	for _, field := range request.CustomFields {
+		if field.Name == "" {
+			return fmt.Errorf("custom field name must not be null")
+		}
+		if len(field.Name) < 1 || len(field.Name) > 256 {
+			return fmt.Errorf("custom field name size must be between 1 and 256 inclusive")
+		}
+		if len(field.Value) > 256 {
+			return fmt.Errorf("custom field value maximum length is 256 characters or can be empty")
+		}
+	}
+
+	if request.AutoRenewDays != 0 && request.AutoRenewDays < 1 {
+		return fmt.Errorf("autoRenewDays must be at least 1")
+	}
+
+	return nil
+}
+
+// EnrollSSL submits a CSR to enroll a new SSL certificate
+func (c *Client) EnrollSSL(ctx context.Context, request EnrollSSLRequest) (*EnrollSSLResponse, error) {
+	if err := validateEnrollSSLRequest(request); err != nil {
+		return nil, err
+	}
+
+	baseURL, err := url.Parse(fmt.Sprintf("%s/api/ssl/v1/enroll", c.BaseURL))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing base URL: %v", err)
+	}
+
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling request body: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL.String(), bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json;charset=UTF-8")
+	req.Header.Set("Accept", "application/json")
+
+	_, body, err := c.sendRequest(ctx, req, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var enrollResponse EnrollSSLResponse
+	err = json.Unmarshal(body, &enrollResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling response: %v", err)
+	}
+
+	return &enrollResponse, nil
+}
+
 // validateUpdateSSLDetailsRequest validates the request parameters
 func validateUpdateSSLDetailsRequest(request UpdateSSLDetailsRequest) error {
 	if request.SSLId < 1 {
@@ -387,12 +1212,8 @@ func validateUpdateSSLDetailsRequest(request UpdateSSLDetailsRequest) error {
 	}
 
 	if request.CSR != "" {
-		csrRegex := regexp.MustCompile(`^[a-zA-Z0-9-+=\/\s]+$`)
-		if !csrRegex.MatchString(request.CSR) {
-			return fmt.Errorf("csr must match the regular expression [a-zA-Z0-9-+=\\/\\s]+")
-		}
-		if len(request.CSR) > 32767 {
-			return fmt.Errorf("csr size must be between 1 and 32767 inclusive")
+		if err := validateCSR(request.CSR); err != nil {
+			return err
 		}
 	}
 
@@ -1,11 +1,23 @@
 package sectigo
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -443,3 +455,1155 @@ func TestValidateUpdateSSLDetailsRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestEnrollSSL(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/enroll", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "application/json;charset=UTF-8", r.Header.Get("Content-Type"))
+
+		var request EnrollSSLRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, request.OrgId)
+		assert.Equal(t, 17, request.CertType)
+		assert.Equal(t, 365, request.Term)
+		assert.Equal(t, "www.example.com,api.example.com", request.SubjAltNames)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(EnrollSSLResponse{
+			SSLId:   1740,
+			RenewId: "renew-1740",
+		})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	enrollResponse, err := client.EnrollSSL(ctx, EnrollSSLRequest{
+		OrgId:        1,
+		CertType:     17,
+		Term:         365,
+		CSR:          "-----BEGIN CERTIFICATE REQUEST-----\nMIIB\n-----END CERTIFICATE REQUEST-----",
+		SubjAltNames: "www.example.com,api.example.com",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1740, enrollResponse.SSLId)
+	assert.Equal(t, "renew-1740", enrollResponse.RenewId)
+}
+
+func TestEnrollSSL_Error(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/enroll", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-993,"description":"Certificate orders currently restricted"}`)) //nolint:errcheck
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	_, err := client.EnrollSSL(ctx, EnrollSSLRequest{
+		OrgId:    1,
+		CertType: 17,
+		Term:     365,
+		CSR:      "MIIB",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "400")
+	assert.Contains(t, err.Error(), "Certificate orders currently restricted")
+}
+
+func TestValidateEnrollSSLRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     EnrollSSLRequest
+		expectedErr string
+	}{
+		{
+			name:        "valid request",
+			request:     EnrollSSLRequest{OrgId: 1, CertType: 17, Term: 365, CSR: "MIIB"},
+			expectedErr: "",
+		},
+		{
+			name:        "invalid orgId",
+			request:     EnrollSSLRequest{CertType: 17, Term: 365, CSR: "MIIB"},
+			expectedErr: "orgId must be at least 1",
+		},
+		{
+			name:        "invalid certType",
+			request:     EnrollSSLRequest{OrgId: 1, Term: 365, CSR: "MIIB"},
+			expectedErr: "certType must be at least 1",
+		},
+		{
+			name:        "invalid term",
+			request:     EnrollSSLRequest{OrgId: 1, CertType: 17, CSR: "MIIB"},
+			expectedErr: "term must be at least 1",
+		},
+		{
+			name:        "missing CSR",
+			request:     EnrollSSLRequest{OrgId: 1, CertType: 17, Term: 365},
+			expectedErr: "csr must not be empty",
+		},
+		{
+			name:        "invalid CSR",
+			request:     EnrollSSLRequest{OrgId: 1, CertType: 17, Term: 365, CSR: "invalid_csr!"},
+			expectedErr: "csr must match the regular expression",
+		},
+		{
+			name: "invalid custom field name",
+			request: EnrollSSLRequest{
+				OrgId:        1,
+				CertType:     17,
+				Term:         365,
+				CSR:          "MIIB",
+				CustomFields: []CustomField{{Name: "", Value: "value"}},
+			},
+			expectedErr: "custom field name must not be null",
+		},
+		{
+			name:        "invalid autoRenewDays",
+			request:     EnrollSSLRequest{OrgId: 1, CertType: 17, Term: 365, CSR: "MIIB", AutoRenew: true, AutoRenewDays: -1},
+			expectedErr: "autoRenewDays must be at least 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEnrollSSLRequest(tt.request)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			}
+		})
+	}
+}
+
+// generateTestCertificate returns a DER encoded self-signed certificate for the given common name.
+func generateTestCertificate(t *testing.T, commonName string) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	return der
+}
+
+// buildTestPKCS7 wraps DER encoded certificates into a degenerate PKCS#7 SignedData message.
+func buildTestPKCS7(t *testing.T, certificates ...[]byte) []byte {
+	t.Helper()
+
+	dataContentInfo, err := asn1.Marshal(struct {
+		ContentType asn1.ObjectIdentifier
+	}{asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}})
+	assert.NoError(t, err)
+
+	signedData, err := asn1.Marshal(struct {
+		Version          int
+		DigestAlgorithms asn1.RawValue
+		ContentInfo      asn1.RawValue
+		Certificates     asn1.RawValue
+		SignerInfos      asn1.RawValue
+	}{
+		Version:          1,
+		DigestAlgorithms: asn1.RawValue{Tag: asn1.TagSet, IsCompound: true},
+		ContentInfo:      asn1.RawValue{FullBytes: dataContentInfo},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: bytes.Join(certificates, nil)},
+		SignerInfos:      asn1.RawValue{Tag: asn1.TagSet, IsCompound: true},
+	})
+	assert.NoError(t, err)
+
+	der, err := asn1.Marshal(struct {
+		ContentType asn1.ObjectIdentifier
+		Content     asn1.RawValue
+	}{
+		ContentType: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2},
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: signedData},
+	})
+	assert.NoError(t, err)
+
+	return der
+}
+
+func TestCollectSSL(t *testing.T) {
+	leaf := generateTestCertificate(t, "example.com")
+	issuer := generateTestCertificate(t, "Test CA")
+	pkcs7 := buildTestPKCS7(t, leaf, issuer)
+
+	tests := []struct {
+		name     string
+		format   CollectFormat
+		response []byte
+		expected []string
+	}{
+		{
+			name:   "x509 chain",
+			format: CollectFormatX509,
+			response: append(
+				pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf}),
+				pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: issuer})...,
+			),
+			expected: []string{"example.com", "Test CA"},
+		},
+		{
+			name:     "x509 certificate only",
+			format:   CollectFormatX509CO,
+			response: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf}),
+			expected: []string{"example.com"},
+		},
+		{
+			name:     "pkcs7 base64 with headers",
+			format:   CollectFormatBase64,
+			response: pem.EncodeToMemory(&pem.Block{Type: "PKCS7", Bytes: pkcs7}),
+			expected: []string{"example.com", "Test CA"},
+		},
+		{
+			name:     "pkcs7 base64 without headers",
+			format:   CollectFormatBase64,
+			response: []byte(base64.StdEncoding.EncodeToString(pkcs7)),
+			expected: []string{"example.com", "Test CA"},
+		},
+		{
+			name:     "pkcs7 binary",
+			format:   CollectFormatBin,
+			response: pkcs7,
+			expected: []string{"example.com", "Test CA"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := NewMockClient()
+			defer mockClient.Close()
+
+			mockClient.Mux.HandleFunc("/api/ssl/v1/collect/1740/"+string(tt.format), func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "GET", r.Method)
+				w.WriteHeader(http.StatusOK)
+				w.Write(tt.response) //nolint:errcheck
+			})
+
+			client := NewClient(Config{
+				URL:      mockClient.Server.URL,
+				Username: "test",
+				Customer: "test",
+				Password: "test",
+				Debug:    false,
+			})
+			client.Client = mockClient.Client
+
+			ctx := context.Background()
+			collectResponse, err := client.CollectSSL(ctx, 1740, tt.format)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.format, collectResponse.Format)
+			assert.Equal(t, tt.response, collectResponse.Data)
+			assert.Equal(t, len(tt.expected), len(collectResponse.Certificates))
+			for i, commonName := range tt.expected {
+				assert.Equal(t, commonName, collectResponse.Certificates[i].Subject.CommonName)
+			}
+		})
+	}
+}
+
+func TestCollectSSL_NotIssued(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/collect/1740/x509", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-183,"description":"Certificate is not yet issued"}`)) //nolint:errcheck
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	_, err := client.CollectSSL(ctx, 1740, CollectFormatX509)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrCertificateNotIssued))
+	assert.True(t, errors.Is(err, ErrBadRequest))
+}
+
+func TestCollectSSL_Error(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/collect/1740/x509", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-104,"description":"Certificate has been revoked"}`)) //nolint:errcheck
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	_, err := client.CollectSSL(ctx, 1740, CollectFormatX509)
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrCertificateNotIssued))
+	assert.Contains(t, err.Error(), "400")
+}
+
+func TestEnrollAndCollect(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	leaf := generateTestCertificate(t, "example.com")
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/enroll", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(EnrollSSLResponse{SSLId: 1740, RenewId: "renew-1740"})
+	})
+
+	polls := 0
+	mockClient.Mux.HandleFunc("/api/ssl/v1/1740", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		status := "Requested"
+		if polls >= 2 {
+			status = "Issued"
+		}
+		polls++
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(SSLDetails{SSLId: 1740, Status: status})
+	})
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/collect/1740/x509CO", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf})) //nolint:errcheck
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	response, err := client.EnrollAndCollect(ctx, EnrollSSLRequest{
+		OrgId:    1,
+		CertType: 17,
+		Term:     365,
+		CSR:      "MIIB",
+	}, EnrollAndCollectOptions{
+		Format:       CollectFormatX509CO,
+		PollInterval: 1 * time.Millisecond,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, 1740, response.SSLId)
+	assert.Equal(t, "renew-1740", response.RenewId)
+	assert.Equal(t, 1, len(response.Certificates))
+	assert.Equal(t, "example.com", response.Certificates[0].Subject.CommonName)
+}
+
+func TestEnrollAndCollect_Rejected(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/enroll", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(EnrollSSLResponse{SSLId: 1740})
+	})
+	mockClient.Mux.HandleFunc("/api/ssl/v1/1740", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(SSLDetails{SSLId: 1740, Status: "Rejected"})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	response, err := client.EnrollAndCollect(ctx, EnrollSSLRequest{
+		OrgId:    1,
+		CertType: 17,
+		Term:     365,
+		CSR:      "MIIB",
+	}, EnrollAndCollectOptions{PollInterval: 1 * time.Millisecond})
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrCertificateRejected))
+	assert.Equal(t, "certificate request was rejected: sslId 1740 has status Rejected", err.Error())
+	assert.Equal(t, 1740, response.SSLId)
+	assert.Nil(t, response.CollectSSLResponse)
+}
+
+func TestEnrollAndCollect_DetailsError(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/enroll", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(EnrollSSLResponse{SSLId: 1740, RenewId: "renew-1740"})
+	})
+	mockClient.Mux.HandleFunc("/api/ssl/v1/1740", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	response, err := client.EnrollAndCollect(context.Background(), EnrollSSLRequest{
+		OrgId:    1,
+		CertType: 17,
+		Term:     365,
+		CSR:      "MIIB",
+	}, EnrollAndCollectOptions{PollInterval: 1 * time.Millisecond})
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	assert.Equal(t, 1740, response.SSLId)
+	assert.Equal(t, "renew-1740", response.RenewId)
+	assert.Nil(t, response.CollectSSLResponse)
+}
+
+func TestEnrollAndCollect_ContextCancelled(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/enroll", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(EnrollSSLResponse{SSLId: 1740})
+	})
+	mockClient.Mux.HandleFunc("/api/ssl/v1/1740", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(SSLDetails{SSLId: 1740, Status: "Requested"})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	response, err := client.EnrollAndCollect(ctx, EnrollSSLRequest{
+		OrgId:    1,
+		CertType: 17,
+		Term:     365,
+		CSR:      "MIIB",
+	}, EnrollAndCollectOptions{PollInterval: 10 * time.Millisecond})
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), "waiting for sslId 1740 to be issued")
+	assert.Equal(t, 1740, response.SSLId)
+}
+
+func TestWaitForSSLIssued(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	polls := 0
+	mockClient.Mux.HandleFunc("/api/ssl/v1/1740", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		status := "Applied"
+		if polls == 3 {
+			status = "Issued"
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(SSLDetails{SSLId: 1740, Status: status})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	var states []string
+	sslDetails, err := client.WaitForSSLIssued(context.Background(), 1740, WaitOptions{
+		Backoff:    ConstantBackoff(time.Millisecond),
+		OnProgress: func(p WaitProgress) { states = append(states, p.State) },
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Issued", sslDetails.Status)
+	assert.Equal(t, []string{"Applied", "Applied"}, states)
+}
+
+func TestWaitForSSLIssued_Declined(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/1740", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(SSLDetails{SSLId: 1740, Status: "Declined"})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	_, err := client.WaitForSSLIssued(context.Background(), 1740, WaitOptions{})
+	assert.True(t, errors.Is(err, ErrCertificateRejected))
+	assert.False(t, errors.Is(err, ErrWaitTimeout))
+}
+
+func TestRenewSSLById(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/renewById/1740", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var request RenewSSLRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		assert.NoError(t, err)
+		assert.Equal(t, "MIIB", request.CSR)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(RenewSSLResponse{SSLId: 1741})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	renewResponse, err := client.RenewSSLById(ctx, 1740, RenewSSLRequest{CSR: "MIIB"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1741, renewResponse.SSLId)
+}
+
+func TestRenewSSLById_Error(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/renewById/1740", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-1,"description":"Certificate cannot be renewed"}`)) //nolint:errcheck
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	_, err := client.RenewSSLById(ctx, 1740, RenewSSLRequest{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "400")
+	assert.Contains(t, err.Error(), "Certificate cannot be renewed")
+
+	_, err = client.RenewSSLById(ctx, 1740, RenewSSLRequest{CSR: "invalid_csr!"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "csr must match the regular expression")
+}
+
+func TestRenewSSLByRenewId(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/renew/renew-1740", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(RenewSSLResponse{SSLId: 1741})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	renewResponse, err := client.RenewSSLByRenewId(ctx, "renew-1740")
+	assert.NoError(t, err)
+	assert.Equal(t, 1741, renewResponse.SSLId)
+
+	_, err = client.RenewSSLByRenewId(ctx, "")
+	assert.Error(t, err)
+	assert.Equal(t, "renewId must not be empty", err.Error())
+}
+
+func TestReplaceSSL(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/replace/1740", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var request ReplaceSSLRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		assert.NoError(t, err)
+		assert.Equal(t, "MIIB", request.CSR)
+		assert.Equal(t, "key compromise", request.Reason)
+		assert.Equal(t, "www.example.com", request.SubjectAlternativeNames)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	err := client.ReplaceSSL(ctx, 1740, ReplaceSSLRequest{
+		CSR:                     "MIIB",
+		Reason:                  "key compromise",
+		SubjectAlternativeNames: "www.example.com",
+	})
+	assert.NoError(t, err)
+}
+
+func TestReplaceSSL_InvalidRequest(t *testing.T) {
+	client := NewClient(Config{
+		URL:      "http://example.com",
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+
+	ctx := context.Background()
+
+	err := client.ReplaceSSL(ctx, 1740, ReplaceSSLRequest{CSR: "MIIB"})
+	assert.Error(t, err)
+	assert.Equal(t, "reason must be between 1 and 512 characters", err.Error())
+
+	err = client.ReplaceSSL(ctx, 1740, ReplaceSSLRequest{Reason: "rekey"})
+	assert.Error(t, err)
+	assert.Equal(t, "csr or subjectAlternativeNames must be provided", err.Error())
+
+	err = client.ReplaceSSL(ctx, 1740, ReplaceSSLRequest{Reason: "rekey", CSR: "invalid_csr!"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "csr must match the regular expression")
+}
+
+func TestApproveSSL(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/approve/1740", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var reqBody map[string]string
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		assert.NoError(t, err)
+		assert.Equal(t, "approved by security", reqBody["message"])
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	err := client.ApproveSSL(ctx, 1740, "approved by security")
+	assert.NoError(t, err)
+}
+
+func TestDeclineSSL(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/decline/1740", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var reqBody map[string]string
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		assert.NoError(t, err)
+		assert.Equal(t, "domain not owned", reqBody["message"])
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	err := client.DeclineSSL(ctx, 1740, "domain not owned")
+	assert.NoError(t, err)
+
+	err = client.DeclineSSL(ctx, 1740, strings.Repeat("a", 513))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "message maximum length is 512 characters")
+}
+
+func TestDeclineSSL_Error(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/decline/1740", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-1,"description":"Certificate is not awaiting approval"}`)) //nolint:errcheck
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	err := client.DeclineSSL(ctx, 1740, "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "400")
+	assert.Contains(t, err.Error(), "Certificate is not awaiting approval")
+}
+
+func TestRevocationReason_String(t *testing.T) {
+	assert.Equal(t, "keyCompromise", RevocationReasonKeyCompromise.String())
+	assert.Equal(t, "superseded", RevocationReasonSuperseded.String())
+	assert.Equal(t, "unknown(7)", RevocationReason(7).String())
+}
+
+func TestSSLDetails_ReasonCode(t *testing.T) {
+	var sslDetails SSLDetails
+	err := json.Unmarshal([]byte(`{"sslId":1740,"status":"Revoked","reasonCode":1}`), &sslDetails)
+	assert.NoError(t, err)
+	assert.Equal(t, RevocationReasonKeyCompromise, sslDetails.ReasonCode)
+}
+
+func TestRevokeSSLBySerial(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/revoke/serial/0A1B2C", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var request RevokeSSLRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		assert.NoError(t, err)
+		assert.Equal(t, RevocationReasonKeyCompromise, request.ReasonCode)
+		assert.Equal(t, "private key leaked", request.Reason)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	err := client.RevokeSSLBySerial(ctx, "0A1B2C", RevokeSSLRequest{
+		ReasonCode: RevocationReasonKeyCompromise,
+		Reason:     "private key leaked",
+	})
+	assert.NoError(t, err)
+}
+
+func TestRevokeSSLBySerial_InvalidRequest(t *testing.T) {
+	client := NewClient(Config{
+		URL:      "http://example.com",
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+
+	ctx := context.Background()
+
+	err := client.RevokeSSLBySerial(ctx, "", RevokeSSLRequest{Reason: "test"})
+	assert.Error(t, err)
+	assert.Equal(t, "serialNumber must not be empty", err.Error())
+
+	err = client.RevokeSSLBySerial(ctx, "0A1B2C", RevokeSSLRequest{ReasonCode: RevocationReasonCACompromise, Reason: "test"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "reasonCode cACompromise is not allowed")
+
+	err = client.RevokeSSLBySerial(ctx, "0A1B2C", RevokeSSLRequest{ReasonCode: RevocationReasonSuperseded})
+	assert.Error(t, err)
+	assert.Equal(t, "reason must be between 1 and 512 characters", err.Error())
+}
+
+func TestRevokeSSLByCert(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	cert, err := x509.ParseCertificate(generateTestCertificate(t, "example.com"))
+	assert.NoError(t, err)
+	serialNumber := certSerialNumberHex(cert)
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("serialNumber") != "" {
+			assert.Equal(t, serialNumber, r.URL.Query().Get("serialNumber"))
+			_ = json.NewEncoder(w).Encode([]SSLCertificate{})
+			return
+		}
+		assert.NotEmpty(t, r.URL.Query().Get("sha1Hash"))
+		_ = json.NewEncoder(w).Encode([]SSLCertificate{{SSLId: 1740, SerialNumber: serialNumber}})
+	})
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/revoke/1740", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var request RevokeSSLRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		assert.NoError(t, err)
+		assert.Equal(t, RevocationReasonSuperseded, request.ReasonCode)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	err = client.RevokeSSLByCert(ctx, cert, RevokeSSLRequest{
+		ReasonCode: RevocationReasonSuperseded,
+		Reason:     "replaced",
+	})
+	assert.NoError(t, err)
+}
+
+func TestRevokeSSLByCert_AmbiguousSerialNumber(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	cert, err := x509.ParseCertificate(generateTestCertificate(t, "example.com"))
+	assert.NoError(t, err)
+
+	var lookups []string
+	mockClient.Mux.HandleFunc("/api/ssl/v1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("serialNumber") != "" {
+			lookups = append(lookups, "serialNumber")
+			_ = json.NewEncoder(w).Encode([]SSLCertificate{{SSLId: 1}, {SSLId: 2}})
+			return
+		}
+		lookups = append(lookups, "sha1Hash")
+		_ = json.NewEncoder(w).Encode([]SSLCertificate{{SSLId: 2}})
+	})
+	mockClient.Mux.HandleFunc("/api/ssl/v1/revoke/2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	err = client.RevokeSSLByCert(context.Background(), cert, RevokeSSLRequest{Reason: "replaced"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"serialNumber", "sha1Hash"}, lookups)
+}
+
+func TestRevokeSSLByCert_AmbiguousFingerprint(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	cert, err := x509.ParseCertificate(generateTestCertificate(t, "example.com"))
+	assert.NoError(t, err)
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode([]SSLCertificate{{SSLId: 1}, {SSLId: 2}})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	err = client.RevokeSSLByCert(context.Background(), cert, RevokeSSLRequest{Reason: "replaced"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "multiple certificates match SHA-1 fingerprint")
+}
+
+func TestRevokeSSLByCert_AmbiguousSerialNumberNoFingerprint(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	cert, err := x509.ParseCertificate(generateTestCertificate(t, "example.com"))
+	assert.NoError(t, err)
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("serialNumber") != "" {
+			_ = json.NewEncoder(w).Encode([]SSLCertificate{{SSLId: 1}, {SSLId: 2}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]SSLCertificate{})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	err = client.RevokeSSLByCert(context.Background(), cert, RevokeSSLRequest{Reason: "replaced"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "multiple certificates match serial number "+certSerialNumberHex(cert))
+	assert.False(t, errors.Is(err, ErrNotFound))
+}
+
+func TestCertSerialNumberHex(t *testing.T) {
+	assert.Equal(t, "0ABC", certSerialNumberHex(&x509.Certificate{SerialNumber: big.NewInt(0xabc)}))
+	assert.Equal(t, "1ABC", certSerialNumberHex(&x509.Certificate{SerialNumber: big.NewInt(0x1abc)}))
+}
+
+func TestRevokeSSLByCert_NotFound(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	cert, err := x509.ParseCertificate(generateTestCertificate(t, "example.com"))
+	assert.NoError(t, err)
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode([]SSLCertificate{})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	err = client.RevokeSSLByCert(ctx, cert, RevokeSSLRequest{Reason: "replaced"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no certificate found with serial number")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestMarkSSLAsRevoked(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/revoke/manual", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var request MarkSSLAsRevokedRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		assert.NoError(t, err)
+		assert.Equal(t, "0A1B2C", request.SerialNumber)
+		assert.Equal(t, "CN=Test CA", request.Issuer)
+		assert.Equal(t, RevocationReasonCessationOfOperation, request.ReasonCode)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	err := client.MarkSSLAsRevoked(ctx, MarkSSLAsRevokedRequest{
+		SerialNumber: "0A1B2C",
+		Issuer:       "CN=Test CA",
+		RevokeDate:   "2024-01-01",
+		ReasonCode:   RevocationReasonCessationOfOperation,
+	})
+	assert.NoError(t, err)
+
+	err = client.MarkSSLAsRevoked(ctx, MarkSSLAsRevokedRequest{})
+	assert.Error(t, err)
+	assert.Equal(t, "certId or serialNumber must be provided", err.Error())
+
+	err = client.MarkSSLAsRevoked(ctx, MarkSSLAsRevokedRequest{SerialNumber: "0A1B2C"})
+	assert.Error(t, err)
+	assert.Equal(t, "issuer must be provided with serialNumber", err.Error())
+}
+
+func TestListSSLTypes(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/types", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "1", r.URL.Query().Get("organizationId"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id":17,"name":"OV SSL","terms":[365],"keyTypes":{"rsa":["2048","4096"],"ec":["P-256"]}}]`)) //nolint:errcheck
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	certTypes, err := client.ListSSLTypes(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(certTypes))
+	assert.Equal(t, 17, certTypes[0].Id)
+	assert.Equal(t, []string{"2048", "4096"}, certTypes[0].KeyTypes.RSA)
+	assert.True(t, certTypes[0].SupportsTerm(365))
+	assert.False(t, certTypes[0].SupportsTerm(730))
+}
+
+func TestListSSLTypes_Error(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/types", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":"Server error"}`)) //nolint:errcheck
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	_, err := client.ListSSLTypes(ctx, 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "500")
+}
+
+func TestListSSLCustomFields(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/customFields", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id":1,"name":"cost center","mandatory":true,"input":{"type":"TEXT_OPTION","options":["it","ops"]}}]`)) //nolint:errcheck
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	customFields, err := client.ListSSLCustomFields(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(customFields))
+	assert.Equal(t, "cost center", customFields[0].Name)
+	assert.True(t, customFields[0].Mandatory)
+	assert.Equal(t, []string{"it", "ops"}, customFields[0].Input.Options)
+}
@@ -1,28 +1,36 @@
 package sectigo
 
 import (
-	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
+	"time"
 )
 
 // Client is a struct that holds the necessary information to make requests to the Sectigo API.
 type Client struct {
-	BaseURL string
-	Client  *http.Client
-	Debug   bool
+	BaseURL         string
+	Client          *http.Client
+	Debug           bool
+	PageConcurrency int
+	Retry           RetryPolicy
+	Instrumentation Instrumentation
+	throttle        *throttle
 }
 
 // authTransport is a custom RoundTripper that adds authentication headers to each request.
 type authTransport struct {
 	login       string
 	customerUri string
+	authMode    AuthMode
 	password    string
+	credentials *credentialCache
+	userAgent   string
 	transport   http.RoundTripper
-	debug       bool
+	logger      *requestLogger
 }
 
 // Config represents the configuration for the Sectigo client.
@@ -32,64 +40,186 @@ type Config struct {
 	Customer string
 	Password string
 	Debug    bool
+	// Logger receives redacted debug logs of every request and response. When nil and Debug is set,
+	// logs are written to stderr.
+	Logger *slog.Logger
+	// LogRedactFields lists the JSON body fields replaced in the logs. Defaults to password, csr and macKey.
+	LogRedactFields []string
+	// LogBodyLimit is the maximum number of body bytes logged. Defaults to 4096, a negative value disables the limit.
+	LogBodyLimit int
+	// CredentialProvider, when set, supplies the password per request instead of Password.
+	CredentialProvider CredentialProvider
+	// CredentialCacheTTL is how long a password from CredentialProvider is cached. 0 caches it until a 401 response.
+	CredentialCacheTTL time.Duration
+	// AuthMode selects password or client certificate authentication. Defaults to AuthModePassword.
+	AuthMode AuthMode
+	// ClientCertificate is the client certificate used by AuthModeClientCertificate.
+	ClientCertificate *tls.Certificate
+	// ClientCertFile and ClientKeyFile are PEM files loaded when ClientCertificate is not set.
+	// ClientKeyFile may be omitted when ClientCertFile also holds the private key.
+	ClientCertFile string
+	ClientKeyFile  string
+	// ClientPKCS12File and ClientPKCS12Password load the client certificate from a PKCS#12 bundle.
+	ClientPKCS12File     string
+	ClientPKCS12Password string
+	// PageConcurrency is the maximum number of pages fetched in parallel by the ListAll* functions.
+	PageConcurrency int
+	// Retry is the policy applied to requests failing with transient errors. Retries are disabled by default.
+	Retry RetryPolicy
+	// RateLimit is the maximum number of requests per second sent by the client. 0 disables rate limiting.
+	RateLimit float64
+	// RateBurst is the number of requests that may be sent at once above RateLimit, at least 1.
+	RateBurst int
+	// MaxInFlight is the maximum number of concurrent requests. 0 means unlimited.
+	MaxInFlight int
+	// OnThrottle, when set, is called with the time each request waited for RateLimit and MaxInFlight.
+	OnThrottle func(ctx context.Context, wait time.Duration)
+	// Instrumentation, when set, is notified of every API call attempt, e.g. to record traces and metrics.
+	Instrumentation Instrumentation
 }
 
 // RoundTrip implements the RoundTripper interface.
+// When a credential provider is configured and the API answers 401, the password is refreshed and the
+// request is sent again once if the password changed.
 func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	password := t.password
+	if t.credentials != nil {
+		var err error
+		password, err = t.credentials.get(req.Context())
+		if err != nil {
+			return nil, fmt.Errorf("error getting credentials: %w", err)
+		}
+	}
+
+	resp, err := t.send(req, password)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || t.credentials == nil {
+		return resp, err
+	}
+
+	t.credentials.invalidate(password)
+	freshPassword, err := t.credentials.get(req.Context())
+	if err != nil || freshPassword == password {
+		return resp, nil
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+	retryReq, err := rewindRequest(req)
+	if err != nil {
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+
+	return t.send(retryReq, freshPassword)
+}
+
+// send sets the authentication headers on req and sends it with the underlying transport.
+func (t *authTransport) send(req *http.Request, password string) (*http.Response, error) {
 	req.Header.Set("login", t.login)
 	req.Header.Set("Content-Type", "application/json;charset=utf-8")
 	req.Header.Set("customerUri", t.customerUri)
-	req.Header.Set("password", t.password)
-
-	if t.debug {
-		log.Printf("Request: %s %s\n", req.Method, req.URL.String())
-		if req.Body != nil {
-			body, _ := io.ReadAll(req.Body)
-			req.Body = io.NopCloser(bytes.NewBuffer(body))
-			log.Printf("Request Body: %s\n", string(body))
-		}
+	if t.authMode != AuthModeClientCertificate {
+		req.Header.Set("password", password)
+	}
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+
+	if !t.logger.enabled(req.Context()) {
+		return t.transport.RoundTrip(req)
 	}
 
+	requestID := t.logger.logRequest(req)
 	resp, err := t.transport.RoundTrip(req)
 	if err != nil {
+		t.logger.logError(req, requestID, err)
 		return nil, err
 	}
-
-	if t.debug {
-		log.Printf("Response Status: %s\n", resp.Status)
-		body, _ := io.ReadAll(resp.Body)
-		resp.Body = io.NopCloser(bytes.NewBuffer(body))
-		log.Printf("Response Body: %s\n", string(body))
-	}
+	t.logger.logResponse(req, requestID, resp)
 
 	return resp, nil
 }
 
 // NewClient initializes a new Sectigo API client with custom headers and optional debug mode.
+// NewClientWithOptions is the recommended constructor: NewClient cannot return configuration errors,
+// such as an unreadable client certificate, so it logs them to config.Logger, or to slog.Default() when
+// no logger is set, and returns a client failing every request with the error.
 func NewClient(config Config) *Client {
-	// Create a new http.Client with the custom RoundTripper
-	client := &http.Client{
-		Transport: &authTransport{
-			login:       config.Username,
-			customerUri: config.Customer,
-			password:    config.Password,
-			transport:   http.DefaultTransport,
-			debug:       config.Debug,
-		},
+	client, err := NewClientWithOptions(config)
+	if err != nil {
+		logger := config.Logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		logger.Error("invalid sectigo client configuration, every request will fail", "error", err)
+		return newClient(config, &http.Client{
+			Transport: RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+				return nil, err
+			}),
+		})
 	}
 
+	return client
+}
+
+// newClient creates a Client sending requests with httpClient.
+func newClient(config Config, httpClient *http.Client) *Client {
 	return &Client{
-		BaseURL: config.URL,
-		Client:  client,
-		Debug:   config.Debug,
+		BaseURL:         config.URL,
+		Client:          httpClient,
+		Debug:           config.Debug,
+		PageConcurrency: config.PageConcurrency,
+		Retry:           config.Retry,
+		Instrumentation: config.Instrumentation,
+		throttle:        newThrottle(config),
 	}
 }
 
 // sendRequest sends an HTTP request and returns the response body.
-// Modified to include response body in error messages for better debugging.
+// Unexpected status codes are reported as an *APIError holding the full response body.
 // expectedStatus can be a specific status code (200, 201, 204, etc.) or 0 to accept any 2xx status code.
+// Transient failures are retried according to the client RetryPolicy.
 func (c *Client) sendRequest(ctx context.Context, req *http.Request, expectedStatus int) (*http.Response, []byte, error) {
 	req = req.WithContext(ctx)
+	retryable := c.Retry.allows(req)
+
+	var operation string
+	if c.Instrumentation != nil {
+		operation = operationName(ctx)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, body, err := c.instrumentedRequest(req, expectedStatus, operation, attempt)
+		if err == nil || !retryable || attempt >= c.Retry.MaxAttempts || !shouldRetry(ctx, resp, err) {
+			return resp, body, err
+		}
+
+		timer := time.NewTimer(c.Retry.backoff(attempt, resp))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, body, err
+		case <-timer.C:
+		}
+
+		req, err = rewindRequest(req)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// doRequest performs a single attempt of req and checks the response status code.
+func (c *Client) doRequest(req *http.Request, expectedStatus int) (*http.Response, []byte, error) {
+	if c.throttle != nil {
+		release, err := c.throttle.acquire(req.Context())
+		if err != nil {
+			return nil, nil, fmt.Errorf("error waiting for rate limiter: %w", err)
+		}
+		defer release()
+	}
+
 	resp, err := c.Client.Do(req)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error making request: %w", err)
@@ -113,12 +243,7 @@ func (c *Client) sendRequest(ctx context.Context, req *http.Request, expectedSta
 	}
 
 	if !statusOK {
-		// Include response body in error message
-		bodyStr := string(body)
-		if len(bodyStr) > 500 {
-			bodyStr = bodyStr[:500] + "... (truncated)"
-		}
-		return resp, body, fmt.Errorf("failed request, status code: %d, response: %s", resp.StatusCode, bodyStr)
+		return resp, body, newAPIError(req, resp.StatusCode, body)
 	}
 
 	return resp, body, nil
@@ -103,6 +103,28 @@ func TestRoundTrip(t *testing.T) {
 	assert.Equal(t, "value", result["key"])
 }
 
+func TestRoundTrip_EmptyPasswordHeaderSent(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{""}, r.Header.Values("password"))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+	})
+
+	req, _ := http.NewRequest("GET", mockClient.Server.URL+"/test", nil)
+	resp, err := client.Client.Transport.RoundTrip(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	_ = resp.Body.Close()
+}
+
 func TestRoundTrip_WithDebug(t *testing.T) {
 	mockClient := NewMockClient()
 	defer mockClient.Close()
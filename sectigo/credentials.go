@@ -0,0 +1,158 @@
+package sectigo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialProvider supplies the API password. It is consulted per request through a cache that is
+// refreshed when the API answers 401 Unauthorized, so passwords can rotate without rebuilding the client.
+type CredentialProvider interface {
+	Password(ctx context.Context) (string, error)
+}
+
+// CredentialProviderFunc adapts a function to the CredentialProvider interface.
+type CredentialProviderFunc func(ctx context.Context) (string, error)
+
+// Password implements the CredentialProvider interface.
+func (f CredentialProviderFunc) Password(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// EnvCredentialProvider reads the password from an environment variable.
+type EnvCredentialProvider struct {
+	Name string
+}
+
+// Password implements the CredentialProvider interface.
+func (p EnvCredentialProvider) Password(ctx context.Context) (string, error) {
+	password, ok := os.LookupEnv(p.Name)
+	if !ok || password == "" {
+		return "", fmt.Errorf("environment variable %s is not set", p.Name)
+	}
+	return password, nil
+}
+
+// FileCredentialProvider reads the password from a file, reloading it whenever the file changes.
+// Surrounding whitespace is trimmed.
+type FileCredentialProvider struct {
+	Path string
+
+	mu       sync.Mutex
+	password string
+	modTime  time.Time
+	size     int64
+}
+
+// NewFileCredentialProvider creates a FileCredentialProvider watching path.
+func NewFileCredentialProvider(path string) *FileCredentialProvider {
+	return &FileCredentialProvider{Path: path}
+}
+
+// Password implements the CredentialProvider interface.
+func (p *FileCredentialProvider) Password(ctx context.Context) (string, error) {
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return "", fmt.Errorf("error reading password file: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.password != "" && info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return p.password, nil
+	}
+
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return "", fmt.Errorf("error reading password file: %w", err)
+	}
+	password := strings.TrimSpace(string(data))
+	if password == "" {
+		return "", fmt.Errorf("password file %s is empty", p.Path)
+	}
+
+	p.password = password
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+
+	return password, nil
+}
+
+// CommandCredentialProvider runs a command and uses its trimmed standard output as the password,
+// for instance to query a secret manager CLI.
+type CommandCredentialProvider struct {
+	Command string
+	Args    []string
+}
+
+// Password implements the CredentialProvider interface.
+func (p CommandCredentialProvider) Password(ctx context.Context) (string, error) {
+	var stderr strings.Builder
+	cmd := exec.CommandContext(ctx, p.Command, p.Args...) // #nosec G204 -- the command is configured by the caller
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("error running credential command %s: %w: %s", p.Command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	password := strings.TrimSpace(string(output))
+	if password == "" {
+		return "", fmt.Errorf("credential command %s returned an empty password", p.Command)
+	}
+	return password, nil
+}
+
+// credentialCache caches the password returned by a CredentialProvider.
+type credentialCache struct {
+	provider CredentialProvider
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	password  string
+	fetchedAt time.Time
+}
+
+// newCredentialCache creates a cache around provider. A ttl of 0 keeps the password until it is rejected.
+func newCredentialCache(provider CredentialProvider, ttl time.Duration) *credentialCache {
+	return &credentialCache{
+		provider: provider,
+		ttl:      ttl,
+	}
+}
+
+// get returns the cached password, fetching it from the provider when missing or expired.
+func (c *credentialCache) get(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.password != "" && (c.ttl <= 0 || time.Since(c.fetchedAt) < c.ttl) {
+		return c.password, nil
+	}
+
+	password, err := c.provider.Password(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.password = password
+	c.fetchedAt = time.Now()
+
+	return password, nil
+}
+
+// invalidate drops the cached password if it is still the one that was rejected.
+func (c *credentialCache) invalidate(rejected string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.password == rejected {
+		c.password = ""
+	}
+}
@@ -0,0 +1,119 @@
+package sectigo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvCredentialProvider(t *testing.T) {
+	t.Setenv("SECTIGO_TEST_PASSWORD", "from-env")
+
+	password, err := EnvCredentialProvider{Name: "SECTIGO_TEST_PASSWORD"}.Password(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "from-env", password)
+
+	_, err = EnvCredentialProvider{Name: "SECTIGO_TEST_MISSING"}.Password(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "SECTIGO_TEST_MISSING is not set")
+}
+
+func TestFileCredentialProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	assert.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+
+	provider := NewFileCredentialProvider(path)
+	password, err := provider.Password(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "first", password)
+
+	assert.NoError(t, os.WriteFile(path, []byte("second-password\n"), 0o600))
+	password, err = provider.Password(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "second-password", password)
+
+	_, err = NewFileCredentialProvider(filepath.Join(t.TempDir(), "missing")).Password(context.Background())
+	assert.Error(t, err)
+}
+
+func TestCommandCredentialProvider(t *testing.T) {
+	password, err := CommandCredentialProvider{Command: "echo", Args: []string{"from-command"}}.Password(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "from-command", password)
+
+	_, err = CommandCredentialProvider{Command: "false"}.Password(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error running credential command false")
+}
+
+func TestCredentialCache(t *testing.T) {
+	calls := 0
+	cache := newCredentialCache(CredentialProviderFunc(func(ctx context.Context) (string, error) {
+		calls++
+		return "password", nil
+	}), 0)
+
+	for range 3 {
+		password, err := cache.get(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "password", password)
+	}
+	assert.Equal(t, 1, calls)
+
+	cache.invalidate("other")
+	_, _ = cache.get(context.Background())
+	assert.Equal(t, 1, calls)
+
+	cache.invalidate("password")
+	_, _ = cache.get(context.Background())
+	assert.Equal(t, 2, calls)
+
+	cache.ttl = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	_, _ = cache.get(context.Background())
+	assert.Equal(t, 3, calls)
+}
+
+func TestCredentialProvider_RefreshOnUnauthorized(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	var received []string
+	mockClient.Mux.HandleFunc("/api/domain/v1", func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("password"))
+		if r.Header.Get("password") != "rotated" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var request DomainRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "example.com", request.Name)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	current := "stale"
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		CredentialProvider: CredentialProviderFunc(func(ctx context.Context) (string, error) {
+			return current, nil
+		}),
+	})
+
+	ctx := context.Background()
+	_, err := client.ListOrganization(ctx)
+	assert.Error(t, err)
+
+	// Rotate the password: the next 401 refreshes it and replays the request with its body.
+	current = "rotated"
+	err = client.CreateDomain(ctx, DomainRequest{Name: "example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"stale", "rotated"}, received)
+}
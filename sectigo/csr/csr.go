@@ -0,0 +1,166 @@
+// Package csr generates private keys and certificate signing requests ready to be submitted to the Sectigo API.
+package csr
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+// Key algorithms supported by the Sectigo API
+const (
+	KeyAlgorithmRSA = "RSA"
+	KeyAlgorithmEC  = "EC"
+)
+
+// Request represents the parameters used to generate a key and its CSR.
+type Request struct {
+	CommonName   string
+	Hostnames    []string
+	Subject      pkix.Name
+	KeyAlgorithm string
+	KeyParam     string
+}
+
+// Result holds a generated private key and its PEM encoded CSR.
+type Result struct {
+	PrivateKey    crypto.Signer
+	PrivateKeyPEM []byte
+	CSR           string
+}
+
+// GenerateKey generates a private key for the given algorithm and parameter,
+// where param is an RSA key size such as "2048" or an EC curve such as "P-256".
+func GenerateKey(algorithm, param string) (crypto.Signer, error) {
+	switch strings.ToUpper(algorithm) {
+	case KeyAlgorithmRSA:
+		if param == "" {
+			param = "2048"
+		}
+		size, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA key size %q: %w", param, err)
+		}
+		if size < 2048 {
+			return nil, fmt.Errorf("RSA key size must be at least 2048 bits")
+		}
+		return rsa.GenerateKey(rand.Reader, size)
+	case KeyAlgorithmEC, "ECDSA":
+		if param == "" {
+			param = "P-256"
+		}
+		curve, err := parseCurve(param)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %q", algorithm)
+	}
+}
+
+// parseCurve maps a curve name as reported by the Sectigo API to its elliptic.Curve.
+func parseCurve(name string) (elliptic.Curve, error) {
+	switch strings.ToUpper(strings.ReplaceAll(name, "-", "")) {
+	case "P256", "SECP256R1", "PRIME256V1":
+		return elliptic.P256(), nil
+	case "P384", "SECP384R1":
+		return elliptic.P384(), nil
+	case "P521", "SECP521R1":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported EC curve %q", name)
+	}
+}
+
+// SupportsKeyType reports whether the certificate profile key types allow the given algorithm and parameter.
+func SupportsKeyType(keyTypes sectigo.KeyTypes, algorithm, param string) bool {
+	switch strings.ToUpper(algorithm) {
+	case KeyAlgorithmRSA:
+		return slices.Contains(keyTypes.RSA, param)
+	case KeyAlgorithmEC, "ECDSA":
+		return slices.ContainsFunc(keyTypes.EC, func(curve string) bool {
+			return strings.EqualFold(curve, param)
+		})
+	default:
+		return false
+	}
+}
+
+// CreateCSR builds a PEM encoded CSR signed by key for the subject and hostnames.
+func CreateCSR(key crypto.Signer, subject pkix.Name, hostnames []string) (string, error) {
+	template := &x509.CertificateRequest{Subject: subject}
+	for _, hostname := range hostnames {
+		if ip := net.ParseIP(hostname); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+			continue
+		}
+		template.DNSNames = append(template.DNSNames, hostname)
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return "", fmt.Errorf("error creating certificate request: %w", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})), nil
+}
+
+// Generate creates a private key and a CSR for the request. When CommonName is empty,
+// the first hostname is used; the common name is always included in the SANs.
+func Generate(request Request) (*Result, error) {
+	commonName := request.CommonName
+	if commonName == "" {
+		if len(request.Hostnames) == 0 {
+			return nil, fmt.Errorf("at least one hostname or a common name is required")
+		}
+		commonName = request.Hostnames[0]
+	}
+
+	hostnames := []string{commonName}
+	for _, hostname := range request.Hostnames {
+		if !slices.Contains(hostnames, hostname) {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+
+	algorithm := request.KeyAlgorithm
+	if algorithm == "" {
+		algorithm = KeyAlgorithmRSA
+	}
+
+	key, err := GenerateKey(algorithm, request.KeyParam)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := request.Subject
+	subject.CommonName = commonName
+	csr, err := CreateCSR(key, subject, hostnames)
+	if err != nil {
+		return nil, err
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling private key: %w", err)
+	}
+
+	return &Result{
+		PrivateKey:    key,
+		PrivateKeyPEM: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		CSR:           csr,
+	}, nil
+}
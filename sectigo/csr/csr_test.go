@@ -0,0 +1,106 @@
+package csr
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"regexp"
+	"testing"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateKey(t *testing.T) {
+	key, err := GenerateKey("RSA", "2048")
+	assert.NoError(t, err)
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	assert.True(t, ok)
+	assert.Equal(t, 2048, rsaKey.N.BitLen())
+
+	key, err = GenerateKey("EC", "P-384")
+	assert.NoError(t, err)
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	assert.True(t, ok)
+	assert.Equal(t, elliptic.P384(), ecKey.Curve)
+
+	key, err = GenerateKey("ec", "secp521r1")
+	assert.NoError(t, err)
+	assert.Equal(t, elliptic.P521(), key.(*ecdsa.PrivateKey).Curve)
+}
+
+func TestGenerateKey_Invalid(t *testing.T) {
+	_, err := GenerateKey("RSA", "1024")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "at least 2048 bits")
+
+	_, err = GenerateKey("RSA", "large")
+	assert.Error(t, err)
+
+	_, err = GenerateKey("EC", "P-224")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported EC curve")
+
+	_, err = GenerateKey("DSA", "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported key algorithm")
+}
+
+func TestSupportsKeyType(t *testing.T) {
+	keyTypes := sectigo.KeyTypes{
+		RSA: []string{"2048", "4096"},
+		EC:  []string{"P-256"},
+	}
+
+	assert.True(t, SupportsKeyType(keyTypes, "RSA", "2048"))
+	assert.False(t, SupportsKeyType(keyTypes, "RSA", "3072"))
+	assert.True(t, SupportsKeyType(keyTypes, "EC", "p-256"))
+	assert.False(t, SupportsKeyType(keyTypes, "EC", "P-384"))
+	assert.False(t, SupportsKeyType(keyTypes, "DSA", "1024"))
+}
+
+func TestGenerate(t *testing.T) {
+	result, err := Generate(Request{
+		Hostnames:    []string{"example.com", "www.example.com", "192.0.2.1"},
+		KeyAlgorithm: "EC",
+		KeyParam:     "P-256",
+	})
+	assert.NoError(t, err)
+
+	// The CSR must be accepted by the Sectigo API CSR validation.
+	assert.Regexp(t, regexp.MustCompile(`^[a-zA-Z0-9-+=\/\s]+$`), result.CSR)
+
+	block, _ := pem.Decode([]byte(result.CSR))
+	assert.NotNil(t, block)
+	assert.Equal(t, "CERTIFICATE REQUEST", block.Type)
+
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	assert.NoError(t, err)
+	assert.NoError(t, request.CheckSignature())
+	assert.Equal(t, "example.com", request.Subject.CommonName)
+	assert.Equal(t, []string{"example.com", "www.example.com"}, request.DNSNames)
+	assert.Equal(t, 1, len(request.IPAddresses))
+
+	keyBlock, _ := pem.Decode(result.PrivateKeyPEM)
+	assert.NotNil(t, keyBlock)
+	assert.Equal(t, "PRIVATE KEY", keyBlock.Type)
+	_, err = x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	assert.NoError(t, err)
+}
+
+func TestGenerate_DefaultsToRSA(t *testing.T) {
+	result, err := Generate(Request{CommonName: "example.com"})
+	assert.NoError(t, err)
+
+	rsaKey, ok := result.PrivateKey.(*rsa.PrivateKey)
+	assert.True(t, ok)
+	assert.Equal(t, 2048, rsaKey.N.BitLen())
+}
+
+func TestGenerate_NoHostname(t *testing.T) {
+	_, err := Generate(Request{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one hostname")
+}
@@ -0,0 +1,98 @@
+// Package dnsprovider implements sectigo.DNSProvider to publish the DNS records of CNAME and TXT domain
+// control validation. The RFC 2136 dynamic update provider is in the rfc2136 subpackage, a separate module.
+package dnsprovider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+// Memory is a DNSProvider keeping the records in memory, for tests. It also implements
+// sectigo.DNSResolver, so the records it presents are immediately visible to ValidateDomainDNS.
+type Memory struct {
+	mu      sync.Mutex
+	records []sectigo.DNSRecord
+}
+
+// NewMemory creates an empty Memory provider.
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Present implements the sectigo.DNSProvider interface. A CNAME record replaces any record with the same name.
+func (m *Memory) Present(ctx context.Context, record sectigo.DNSRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record.Name = canonicalName(record.Name)
+	if record.Type == sectigo.DNSRecordTypeCNAME {
+		m.records = slices.DeleteFunc(m.records, func(r sectigo.DNSRecord) bool { return r.Name == record.Name })
+	}
+	if !slices.Contains(m.records, record) {
+		m.records = append(m.records, record)
+	}
+	return nil
+}
+
+// CleanUp implements the sectigo.DNSProvider interface.
+func (m *Memory) CleanUp(ctx context.Context, record sectigo.DNSRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record.Name = canonicalName(record.Name)
+	m.records = slices.DeleteFunc(m.records, func(r sectigo.DNSRecord) bool { return r == record })
+	return nil
+}
+
+// Records returns the records currently presented.
+func (m *Memory) Records() []sectigo.DNSRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.records)
+}
+
+// LookupCNAME implements the sectigo.DNSResolver interface.
+func (m *Memory) LookupCNAME(ctx context.Context, host string) (string, error) {
+	for _, r := range m.lookup(sectigo.DNSRecordTypeCNAME, host) {
+		return r.Value, nil
+	}
+	return "", &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// LookupTXT implements the sectigo.DNSResolver interface.
+func (m *Memory) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	var values []string
+	for _, r := range m.lookup(sectigo.DNSRecordTypeTXT, name) {
+		values = append(values, r.Value)
+	}
+	if len(values) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return values, nil
+}
+
+// lookup returns the records of recordType named name.
+func (m *Memory) lookup(recordType, name string) []sectigo.DNSRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = canonicalName(name)
+	var records []sectigo.DNSRecord
+	for _, r := range m.records {
+		if r.Type == recordType && r.Name == name {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+// canonicalName returns name in lower case with a trailing dot.
+func canonicalName(name string) string {
+	return fmt.Sprintf("%s.", strings.ToLower(strings.TrimSuffix(name, ".")))
+}
@@ -0,0 +1,58 @@
+package dnsprovider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+	"github.com/fgouteroux/sectigo-client/sectigo/sectigotest"
+)
+
+func TestMemory(t *testing.T) {
+	provider := NewMemory()
+	ctx := context.Background()
+
+	cname := sectigo.DNSRecord{Type: sectigo.DNSRecordTypeCNAME, Name: "_abc.Example.com", Value: "target.sectigo.com."}
+	assert.NoError(t, provider.Present(ctx, cname))
+	assert.NoError(t, provider.Present(ctx, sectigo.DNSRecord{Type: sectigo.DNSRecordTypeTXT, Name: "_dnsauth.example.com.", Value: "one"}))
+	assert.NoError(t, provider.Present(ctx, sectigo.DNSRecord{Type: sectigo.DNSRecordTypeTXT, Name: "_dnsauth.example.com.", Value: "two"}))
+
+	target, err := provider.LookupCNAME(ctx, "_abc.example.com.")
+	assert.NoError(t, err)
+	assert.Equal(t, "target.sectigo.com.", target)
+
+	values, err := provider.LookupTXT(ctx, "_dnsauth.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"one", "two"}, values)
+
+	assert.NoError(t, provider.CleanUp(ctx, cname))
+	_, err = provider.LookupCNAME(ctx, "_abc.example.com.")
+	assert.Error(t, err)
+	assert.Len(t, provider.Records(), 2)
+}
+
+func TestValidateDomainDNS_Memory(t *testing.T) {
+	server := sectigotest.NewServer(sectigotest.WithAutoValidate())
+	defer server.Close()
+	client := server.Client()
+	provider := NewMemory()
+
+	for _, recordType := range []string{sectigo.DNSRecordTypeCNAME, sectigo.DNSRecordTypeTXT} {
+		t.Run(recordType, func(t *testing.T) {
+			domain := "example-" + recordType + ".com"
+			status, err := client.ValidateDomainDNS(context.Background(), domain, provider, sectigo.ValidateDomainDNSOptions{
+				RecordType:          recordType,
+				Resolver:            provider,
+				PropagationInterval: time.Millisecond,
+				Wait:                sectigo.WaitOptions{Backoff: sectigo.ConstantBackoff(time.Millisecond)},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, sectigotest.DCVStatusValidated, status.Status)
+			assert.Equal(t, recordType, server.DomainValidation(domain).DcvMethod)
+			assert.Empty(t, provider.Records())
+		})
+	}
+}
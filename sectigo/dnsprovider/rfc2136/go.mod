@@ -0,0 +1,25 @@
+module github.com/fgouteroux/sectigo-client/sectigo/dnsprovider/rfc2136
+
+go 1.24.0
+
+require (
+	github.com/fgouteroux/sectigo-client v0.0.0-00010101000000-000000000000
+	github.com/miekg/dns v1.1.72
+	github.com/stretchr/testify v1.11.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/crypto v0.46.0 // indirect
+	golang.org/x/mod v0.31.0 // indirect
+	golang.org/x/net v0.48.0 // indirect
+	golang.org/x/sync v0.19.0 // indirect
+	golang.org/x/sys v0.39.0 // indirect
+	golang.org/x/tools v0.40.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+	software.sslmate.com/src/go-pkcs12 v0.7.3 // indirect
+)
+
+replace github.com/fgouteroux/sectigo-client => ../../..
@@ -0,0 +1,171 @@
+// Package rfc2136 implements sectigo.DNSProvider with RFC 2136 dynamic updates, to publish the DNS records of
+// CNAME and TXT domain control validation. It is a separate module, so only its users depend on miekg/dns:
+//
+//	provider := rfc2136.New(rfc2136.Config{
+//		Nameserver:  "ns1.example.com:53",
+//		TSIGKeyName: "sectigo.",
+//		TSIGSecret:  secret,
+//	})
+//	status, err := client.ValidateDomainDNS(ctx, "example.com", provider, sectigo.ValidateDomainDNSOptions{})
+package rfc2136
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+// Defaults of Config.
+const (
+	defaultTTL     = 60
+	defaultTimeout = 10 * time.Second
+	// tsigFudge is the allowed clock skew of TSIG signed messages, in seconds.
+	tsigFudge = 300
+)
+
+// Config represents the configuration of a Provider.
+type Config struct {
+	// Nameserver is the host:port of the primary nameserver accepting dynamic updates.
+	Nameserver string
+	// Zone is the zone updated. When empty, it is the zone of the SOA returned by Nameserver for the record name.
+	Zone string
+	// TSIGKeyName and TSIGSecret authenticate the updates with TSIG when set. TSIGSecret is base64 encoded.
+	TSIGKeyName string
+	TSIGSecret  string
+	// TSIGAlgorithm is the TSIG algorithm. Defaults to hmac-sha256.
+	TSIGAlgorithm string
+	// TTL is the TTL of the published records, in seconds. Defaults to 60.
+	TTL uint32
+	// Net is the transport, "udp" or "tcp". Defaults to "udp".
+	Net string
+	// Timeout bounds each DNS exchange. Defaults to 10 seconds.
+	Timeout time.Duration
+}
+
+// Provider is a sectigo.DNSProvider publishing the records through RFC 2136 dynamic updates.
+type Provider struct {
+	config Config
+	client *dns.Client
+}
+
+// New creates a Provider from config.
+func New(config Config) *Provider {
+	if config.TSIGAlgorithm == "" {
+		config.TSIGAlgorithm = dns.HmacSHA256
+	}
+	config.TSIGAlgorithm = dns.Fqdn(config.TSIGAlgorithm)
+	if config.TTL == 0 {
+		config.TTL = defaultTTL
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	client := &dns.Client{Net: config.Net, Timeout: config.Timeout}
+	if config.TSIGKeyName != "" {
+		config.TSIGKeyName = dns.Fqdn(config.TSIGKeyName)
+		client.TsigSecret = map[string]string{config.TSIGKeyName: config.TSIGSecret}
+	}
+
+	return &Provider{config: config, client: client}
+}
+
+// Present implements the sectigo.DNSProvider interface. A CNAME record replaces the records with the same name,
+// a TXT record is added next to the existing ones.
+func (p *Provider) Present(ctx context.Context, record sectigo.DNSRecord) error {
+	rr, err := p.resourceRecord(record)
+	if err != nil {
+		return err
+	}
+
+	zone, err := p.zone(ctx, rr.Header().Name)
+	if err != nil {
+		return err
+	}
+
+	m := new(dns.Msg)
+	m.SetUpdate(zone)
+	if record.Type == sectigo.DNSRecordTypeCNAME {
+		m.RemoveName([]dns.RR{rr})
+	}
+	m.Insert([]dns.RR{rr})
+	return p.update(ctx, m)
+}
+
+// CleanUp implements the sectigo.DNSProvider interface.
+func (p *Provider) CleanUp(ctx context.Context, record sectigo.DNSRecord) error {
+	rr, err := p.resourceRecord(record)
+	if err != nil {
+		return err
+	}
+
+	zone, err := p.zone(ctx, rr.Header().Name)
+	if err != nil {
+		return err
+	}
+
+	m := new(dns.Msg)
+	m.SetUpdate(zone)
+	m.Remove([]dns.RR{rr})
+	return p.update(ctx, m)
+}
+
+// resourceRecord converts record to a DNS resource record.
+func (p *Provider) resourceRecord(record sectigo.DNSRecord) (dns.RR, error) {
+	header := dns.RR_Header{Name: dns.Fqdn(record.Name), Class: dns.ClassINET, Ttl: p.config.TTL}
+	switch record.Type {
+	case sectigo.DNSRecordTypeCNAME:
+		header.Rrtype = dns.TypeCNAME
+		return &dns.CNAME{Hdr: header, Target: dns.Fqdn(record.Value)}, nil
+	case sectigo.DNSRecordTypeTXT:
+		header.Rrtype = dns.TypeTXT
+		return &dns.TXT{Hdr: header, Txt: []string{record.Value}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported DNS record type %q", record.Type)
+	}
+}
+
+// zone returns the configured zone, or the zone of the SOA returned by the nameserver for name.
+func (p *Provider) zone(ctx context.Context, name string) (string, error) {
+	if p.config.Zone != "" {
+		return dns.Fqdn(p.config.Zone), nil
+	}
+
+	m := new(dns.Msg)
+	m.SetQuestion(name, dns.TypeSOA)
+	reply, _, err := p.client.ExchangeContext(ctx, m, p.config.Nameserver)
+	if err != nil {
+		return "", fmt.Errorf("error looking up the zone of %s: %w", name, err)
+	}
+	for _, rr := range append(reply.Answer, reply.Ns...) {
+		if soa, ok := rr.(*dns.SOA); ok {
+			return soa.Hdr.Name, nil
+		}
+	}
+	return "", fmt.Errorf("no SOA record found for %s at %s", name, p.config.Nameserver)
+}
+
+// update sends the update message m to the nameserver.
+func (p *Provider) update(ctx context.Context, m *dns.Msg) error {
+	if p.config.TSIGKeyName != "" {
+		m.SetTsig(p.config.TSIGKeyName, p.config.TSIGAlgorithm, tsigFudge, time.Now().Unix())
+	}
+
+	reply, _, err := p.client.ExchangeContext(ctx, m, p.config.Nameserver)
+	if err != nil {
+		return fmt.Errorf("error sending DNS update to %s: %w", p.config.Nameserver, err)
+	}
+	if reply.Rcode != dns.RcodeSuccess {
+		rcode, ok := dns.RcodeToString[reply.Rcode]
+		if !ok {
+			rcode = strconv.Itoa(reply.Rcode)
+		}
+		return fmt.Errorf("DNS update rejected by %s: %s", p.config.Nameserver, rcode)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package rfc2136
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+// testNameserver is a nameserver authoritative for example.com recording the dynamic updates it receives.
+type testNameserver struct {
+	addr string
+
+	mu      sync.Mutex
+	updates []*dns.Msg
+	tsigErr []error
+}
+
+// startTestNameserver starts a testNameserver accepting the TSIG key keyName with secret.
+func startTestNameserver(t *testing.T, keyName, secret string) *testNameserver {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := &testNameserver{addr: conn.LocalAddr().String()}
+	server := &dns.Server{
+		PacketConn: conn,
+		TsigSecret: map[string]string{keyName: secret},
+		Handler:    dns.HandlerFunc(ns.serveDNS),
+		// The default accept function answers NOTIMP to updates.
+		MsgAcceptFunc: func(dh dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+	}
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+	go server.ActivateAndServe() //nolint:errcheck
+	<-started
+	t.Cleanup(func() { _ = server.Shutdown() })
+	return ns
+}
+
+func (ns *testNameserver) serveDNS(w dns.ResponseWriter, r *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetReply(r)
+
+	switch r.Opcode {
+	case dns.OpcodeQuery:
+		m.Ns = []dns.RR{&dns.SOA{
+			Hdr: dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 60},
+			Ns:  "ns1.example.com.", Mbox: "hostmaster.example.com.", Serial: 1, Refresh: 60, Retry: 60, Expire: 60, Minttl: 60,
+		}}
+	case dns.OpcodeUpdate:
+		ns.mu.Lock()
+		ns.updates = append(ns.updates, r)
+		ns.tsigErr = append(ns.tsigErr, w.TsigStatus())
+		ns.mu.Unlock()
+		if r.IsTsig() == nil || w.TsigStatus() != nil {
+			m.Rcode = dns.RcodeRefused
+		} else {
+			m.SetTsig(r.Extra[len(r.Extra)-1].(*dns.TSIG).Hdr.Name, dns.HmacSHA256, tsigFudge, time.Now().Unix())
+		}
+	}
+	_ = w.WriteMsg(m)
+}
+
+func TestProvider(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	ns := startTestNameserver(t, "sectigo.", secret)
+	provider := New(Config{Nameserver: ns.addr, TSIGKeyName: "sectigo", TSIGSecret: secret})
+	ctx := context.Background()
+
+	cname := sectigo.DNSRecord{Type: sectigo.DNSRecordTypeCNAME, Name: "_abc.example.com.", Value: "target.sectigo.com"}
+	assert.NoError(t, provider.Present(ctx, cname))
+	assert.NoError(t, provider.CleanUp(ctx, cname))
+	assert.NoError(t, provider.Present(ctx, sectigo.DNSRecord{Type: sectigo.DNSRecordTypeTXT, Name: "_dnsauth.example.com", Value: "value"}))
+
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	if !assert.Len(t, ns.updates, 3) {
+		return
+	}
+	for _, err := range ns.tsigErr {
+		assert.NoError(t, err)
+	}
+
+	present := ns.updates[0]
+	assert.Equal(t, "example.com.", present.Question[0].Name)
+	if !assert.Len(t, present.Ns, 2) {
+		return
+	}
+	assert.Equal(t, uint16(dns.ClassANY), present.Ns[0].Header().Class)
+	assert.Equal(t, uint16(dns.TypeANY), present.Ns[0].Header().Rrtype)
+	assert.Equal(t, "_abc.example.com.\t60\tIN\tCNAME\ttarget.sectigo.com.", present.Ns[1].String())
+
+	cleanup := ns.updates[1]
+	if !assert.Len(t, cleanup.Ns, 1) {
+		return
+	}
+	assert.Equal(t, uint16(dns.ClassNONE), cleanup.Ns[0].Header().Class)
+	assert.Equal(t, "target.sectigo.com.", cleanup.Ns[0].(*dns.CNAME).Target)
+
+	txt := ns.updates[2]
+	if !assert.Len(t, txt.Ns, 1) {
+		return
+	}
+	assert.Equal(t, []string{"value"}, txt.Ns[0].(*dns.TXT).Txt)
+}
+
+func TestProvider_Rejected(t *testing.T) {
+	ns := startTestNameserver(t, "sectigo.", base64.StdEncoding.EncodeToString([]byte("secret")))
+	provider := New(Config{Nameserver: ns.addr, Zone: "example.com"})
+
+	err := provider.Present(context.Background(), sectigo.DNSRecord{Type: sectigo.DNSRecordTypeTXT, Name: "_dnsauth.example.com.", Value: "value"})
+	assert.ErrorContains(t, err, "REFUSED")
+}
+
+func TestProvider_UnsupportedType(t *testing.T) {
+	provider := New(Config{Nameserver: "127.0.0.1:53", Zone: "example.com"})
+
+	err := provider.Present(context.Background(), sectigo.DNSRecord{Type: "A", Name: "example.com.", Value: "127.0.0.1"})
+	assert.ErrorContains(t, err, "unsupported DNS record type")
+}
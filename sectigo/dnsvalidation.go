@@ -0,0 +1,181 @@
+package sectigo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DNS record types published for domain control validation.
+const (
+	DNSRecordTypeCNAME = "CNAME"
+	DNSRecordTypeTXT   = "TXT"
+)
+
+// DNSRecord is a DNS record published to prove control of a domain.
+type DNSRecord struct {
+	// Type is DNSRecordTypeCNAME or DNSRecordTypeTXT.
+	Type string
+	// Name is the fully qualified name of the record.
+	Name string
+	// Value is the CNAME target or the TXT record value.
+	Value string
+}
+
+// DNSProvider publishes and removes the DNS records used for CNAME and TXT domain control validation.
+type DNSProvider interface {
+	// Present publishes record.
+	Present(ctx context.Context, record DNSRecord) error
+	// CleanUp removes a record previously published by Present.
+	CleanUp(ctx context.Context, record DNSRecord) error
+}
+
+// DNSResolver looks up the records published by a DNSProvider to check their propagation.
+// *net.Resolver implements it.
+type DNSResolver interface {
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// ValidateDomainDNSOptions represents the options used by ValidateDomainDNS.
+type ValidateDomainDNSOptions struct {
+	// RecordType selects CNAME or TXT validation. Defaults to DNSRecordTypeCNAME.
+	RecordType string
+	// Resolver checks the propagation of the published record. Defaults to net.DefaultResolver.
+	Resolver DNSResolver
+	// PropagationInterval is the interval between propagation checks. Defaults to 5 seconds.
+	PropagationInterval time.Duration
+	// PropagationTimeout bounds the wait for the record to propagate. Defaults to 2 minutes.
+	PropagationTimeout time.Duration
+	// Wait controls the polling of the validation status once submitted, see WaitForDomainValidation.
+	Wait WaitOptions
+}
+
+// ValidateDomainDNS proves control of domain through a DNS record: it starts a CNAME or TXT validation,
+// publishes the record with provider, waits until the resolver sees it, submits the validation and polls
+// the validation status with WaitForDomainValidation. The record is cleaned up on return,
+// and a cleanup failure is reported in the returned error even when the domain was validated.
+func (c *Client) ValidateDomainDNS(ctx context.Context, domain string, provider DNSProvider, options ValidateDomainDNSOptions) (_ *GetDomainValidationStatusResponse, err error) {
+	if options.RecordType == "" {
+		options.RecordType = DNSRecordTypeCNAME
+	}
+	if options.Resolver == nil {
+		options.Resolver = net.DefaultResolver
+	}
+	if options.PropagationInterval <= 0 {
+		options.PropagationInterval = 5 * time.Second
+	}
+	if options.PropagationTimeout <= 0 {
+		options.PropagationTimeout = 2 * time.Minute
+	}
+
+	record, err := c.startDNSValidation(ctx, domain, options.RecordType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := provider.Present(ctx, record); err != nil {
+		return nil, fmt.Errorf("error presenting %s record %s: %w", record.Type, record.Name, err)
+	}
+	defer func() {
+		// The record is removed even when ctx is canceled.
+		if cleanupErr := provider.CleanUp(context.WithoutCancel(ctx), record); cleanupErr != nil {
+			err = errors.Join(err, fmt.Errorf("error cleaning up %s record %s: %w", record.Type, record.Name, cleanupErr))
+		}
+	}()
+
+	if err := waitForDNSPropagation(ctx, options.Resolver, record, options.PropagationInterval, options.PropagationTimeout); err != nil {
+		return nil, err
+	}
+
+	if err := c.submitDNSValidation(ctx, domain, options.RecordType); err != nil {
+		return nil, err
+	}
+
+	return c.WaitForDomainValidation(ctx, domain, options.Wait)
+}
+
+// startDNSValidation starts the validation of domain with recordType and returns the record to publish.
+func (c *Client) startDNSValidation(ctx context.Context, domain, recordType string) (DNSRecord, error) {
+	switch recordType {
+	case DNSRecordTypeCNAME:
+		response, err := c.StartDomainCNameValidation(ctx, StartDomainCNameValidationRequest{Domain: domain})
+		if err != nil {
+			return DNSRecord{}, err
+		}
+		return DNSRecord{Type: recordType, Name: response.Host, Value: response.Point}, nil
+	case DNSRecordTypeTXT:
+		response, err := c.StartDomainTXTValidation(ctx, StartDomainTXTValidationRequest{Domain: domain})
+		if err != nil {
+			return DNSRecord{}, err
+		}
+		return DNSRecord{Type: recordType, Name: response.Host, Value: response.Value}, nil
+	default:
+		return DNSRecord{}, fmt.Errorf("unsupported DNS record type %q", recordType)
+	}
+}
+
+// submitDNSValidation submits the validation of domain started with recordType.
+func (c *Client) submitDNSValidation(ctx context.Context, domain, recordType string) error {
+	var err error
+	switch recordType {
+	case DNSRecordTypeCNAME:
+		_, err = c.SubmitDomainCNameValidation(ctx, SubmitDomainCNameValidationRequest{Domain: domain})
+	case DNSRecordTypeTXT:
+		_, err = c.SubmitDomainTXTValidation(ctx, SubmitDomainTXTValidationRequest{Domain: domain})
+	}
+	return err
+}
+
+// waitForDNSPropagation polls resolver every interval until it returns record, for at most timeout.
+func waitForDNSPropagation(ctx context.Context, resolver DNSResolver, record DNSRecord, interval, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		found, err := lookupDNSRecord(ctx, resolver, record)
+		if found {
+			return nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if err != nil {
+				return fmt.Errorf("waiting for %s record %s to propagate: %w", record.Type, record.Name, errors.Join(ctx.Err(), err))
+			}
+			return fmt.Errorf("waiting for %s record %s to propagate: %w", record.Type, record.Name, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// lookupDNSRecord reports whether resolver returns record.
+func lookupDNSRecord(ctx context.Context, resolver DNSResolver, record DNSRecord) (bool, error) {
+	switch record.Type {
+	case DNSRecordTypeCNAME:
+		target, err := resolver.LookupCNAME(ctx, record.Name)
+		if err != nil {
+			return false, err
+		}
+		return normalizeDNSName(target) == normalizeDNSName(record.Value), nil
+	case DNSRecordTypeTXT:
+		values, err := resolver.LookupTXT(ctx, record.Name)
+		if err != nil {
+			return false, err
+		}
+		return slices.Contains(values, record.Value), nil
+	default:
+		return false, fmt.Errorf("unsupported DNS record type %q", record.Type)
+	}
+}
+
+// normalizeDNSName returns name in lower case without its trailing dot.
+func normalizeDNSName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
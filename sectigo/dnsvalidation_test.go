@@ -0,0 +1,123 @@
+package sectigo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingDNSProvider records the records presented and cleaned up, and resolves the presented ones.
+type recordingDNSProvider struct {
+	presented  []DNSRecord
+	cleanedUp  []DNSRecord
+	visible    bool
+	cleanupErr error
+}
+
+func (p *recordingDNSProvider) Present(ctx context.Context, record DNSRecord) error {
+	p.presented = append(p.presented, record)
+	return nil
+}
+
+func (p *recordingDNSProvider) CleanUp(ctx context.Context, record DNSRecord) error {
+	p.cleanedUp = append(p.cleanedUp, record)
+	return p.cleanupErr
+}
+
+func (p *recordingDNSProvider) LookupCNAME(ctx context.Context, host string) (string, error) {
+	if !p.visible || len(p.presented) == 0 {
+		return "", errors.New("no such host")
+	}
+	return p.presented[0].Value, nil
+}
+
+func (p *recordingDNSProvider) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if !p.visible || len(p.presented) == 0 {
+		return nil, errors.New("no such host")
+	}
+	return []string{p.presented[0].Value}, nil
+}
+
+// newDNSValidationMockClient returns a client whose CNAME validation validates the domain after statusCalls status requests.
+func newDNSValidationMockClient(statusCalls int, submitted *bool) (*MockClient, *Client) {
+	mockClient := NewMockClient()
+	mockClient.Mux.HandleFunc("/api/dcv/v1/validation/start/domain/cname", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(StartDomainCNameValidationResponse{Host: "_abc.example.com.", Point: "Target.Sectigo.com"})
+	})
+	mockClient.Mux.HandleFunc("/api/dcv/v1/validation/submit/domain/cname", func(w http.ResponseWriter, r *http.Request) {
+		*submitted = true
+		_ = json.NewEncoder(w).Encode(SubmitDomainCNameValidationResponse{OrderStatus: "SUBMITTED"})
+	})
+	calls := 0
+	mockClient.Mux.HandleFunc("/api/dcv/v2/validation/status", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		status := "NOT_VALIDATED"
+		if calls >= statusCalls {
+			status = DomainValidationStatusValidated
+		}
+		_ = json.NewEncoder(w).Encode(GetDomainValidationStatusResponse{Status: status, OrderStatus: "SUBMITTED"})
+	})
+
+	client := NewClient(Config{URL: mockClient.Server.URL, Username: "test", Customer: "test", Password: "test"})
+	client.Client = mockClient.Client
+	return mockClient, client
+}
+
+func TestValidateDomainDNS(t *testing.T) {
+	var submitted bool
+	mockClient, client := newDNSValidationMockClient(3, &submitted)
+	defer mockClient.Close()
+
+	provider := &recordingDNSProvider{visible: true}
+	status, err := client.ValidateDomainDNS(context.Background(), "example.com", provider, ValidateDomainDNSOptions{
+		Resolver: provider,
+		Wait:     WaitOptions{Backoff: ConstantBackoff(time.Millisecond)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, DomainValidationStatusValidated, status.Status)
+	assert.True(t, submitted)
+	record := DNSRecord{Type: DNSRecordTypeCNAME, Name: "_abc.example.com.", Value: "Target.Sectigo.com"}
+	assert.Equal(t, []DNSRecord{record}, provider.presented)
+	assert.Equal(t, []DNSRecord{record}, provider.cleanedUp)
+}
+
+func TestValidateDomainDNS_PropagationTimeout(t *testing.T) {
+	var submitted bool
+	mockClient, client := newDNSValidationMockClient(1, &submitted)
+	defer mockClient.Close()
+
+	provider := &recordingDNSProvider{}
+	_, err := client.ValidateDomainDNS(context.Background(), "example.com", provider, ValidateDomainDNSOptions{
+		Resolver:            provider,
+		PropagationInterval: time.Millisecond,
+		PropagationTimeout:  20 * time.Millisecond,
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "no such host")
+	assert.False(t, submitted)
+	assert.Len(t, provider.cleanedUp, 1)
+}
+
+func TestValidateDomainDNS_CleanUpError(t *testing.T) {
+	var submitted bool
+	mockClient, client := newDNSValidationMockClient(1, &submitted)
+	defer mockClient.Close()
+
+	cleanupErr := errors.New("update refused")
+	provider := &recordingDNSProvider{visible: true, cleanupErr: cleanupErr}
+	status, err := client.ValidateDomainDNS(context.Background(), "example.com", provider, ValidateDomainDNSOptions{Resolver: provider})
+	assert.ErrorIs(t, err, cleanupErr)
+	assert.Equal(t, DomainValidationStatusValidated, status.Status)
+}
+
+func TestValidateDomainDNS_UnsupportedRecordType(t *testing.T) {
+	client := NewClient(Config{URL: "http://127.0.0.1", Username: "test", Customer: "test", Password: "test"})
+
+	_, err := client.ValidateDomainDNS(context.Background(), "example.com", &recordingDNSProvider{}, ValidateDomainDNSOptions{RecordType: "A"})
+	assert.ErrorContains(t, err, "unsupported DNS record type")
+}
@@ -4,14 +4,31 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"net/url"
+	"path"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// ErrDomainIDUnknown is returned by CreateDomainWithID when the domain was created but its ID could not be determined.
+var ErrDomainIDUnknown = errors.New("domain created but its ID is unknown")
+
+// ErrDomainValidationFailed is returned by WaitForDomainValidation when the validation reaches a failed or expired status.
+var ErrDomainValidationFailed = errors.New("domain validation failed")
+
+// Domain control validation statuses.
+const (
+	DomainValidationStatusNotValidated = "NOT_VALIDATED"
+	DomainValidationStatusValidated    = "VALIDATED"
+	DomainValidationStatusExpired      = "EXPIRED"
+	DomainValidationStatusFailed       = "FAILED"
+)
+
 // DomainRequest represents the structure of the JSON payload for the domain creation request.
 type DomainRequest struct {
 	Name        string              `json:"name"`
@@ -20,6 +37,14 @@ type DomainRequest struct {
 	Delegations []DelegationRequest `json:"delegations"`
 }
 
+// UpdateDomainRequest represents the structure of the JSON payload for the domain update request.
+// Nil fields are left unchanged, a non-nil empty Delegations removes all the delegations.
+type UpdateDomainRequest struct {
+	Description *string              `json:"description,omitempty"`
+	Active      *bool                `json:"active,omitempty"`
+	Delegations *[]DelegationRequest `json:"delegations,omitempty"`
+}
+
 type DelegationRequest struct {
 	OrgId     int      `json:"orgId"`
 	CertTypes []string `json:"certTypes"`
@@ -57,6 +82,7 @@ type Domain struct {
 type DomainDetails struct {
 	ID               int    `json:"id"`
 	Name             string `json:"name"`
+	Description      string `json:"description"`
 	DelegationStatus string `json:"delegationStatus"`
 	State            string `json:"state"`
 	ValidationStatus string `json:"validationStatus"`
@@ -104,6 +130,85 @@ type SubmitDomainCNameValidationResponse struct {
 	Status      string `json:"status"`
 }
 
+// StartDomainHTTPValidationRequest represents the structure of the JSON payload for starting HTTP or HTTPS file validation.
+type StartDomainHTTPValidationRequest struct {
+	Domain string `json:"domain"`
+}
+
+// StartDomainHTTPValidationResponse represents the response structure for starting HTTP or HTTPS file validation.
+// The file served at URL must contain FirstLine and SecondLine, see FileContents.
+type StartDomainHTTPValidationResponse struct {
+	URL        string `json:"url"`
+	FirstLine  string `json:"firstLine"`
+	SecondLine string `json:"secondLine"`
+}
+
+// FileContents returns the contents of the validation file to serve at URL.
+func (r *StartDomainHTTPValidationResponse) FileContents() string {
+	return r.FirstLine + "\n" + r.SecondLine
+}
+
+// SubmitDomainHTTPValidationRequest represents the structure of the JSON payload for submitting HTTP or HTTPS file validation.
+type SubmitDomainHTTPValidationRequest struct {
+	Domain string `json:"domain"`
+}
+
+// SubmitDomainHTTPValidationResponse represents the response structure for submitting HTTP or HTTPS file validation.
+type SubmitDomainHTTPValidationResponse struct {
+	OrderStatus string `json:"orderStatus"`
+	Message     string `json:"message"`
+	Status      string `json:"status"`
+}
+
+// StartDomainTXTValidationRequest represents the structure of the JSON payload for starting TXT record validation.
+type StartDomainTXTValidationRequest struct {
+	Domain string `json:"domain"`
+}
+
+// StartDomainTXTValidationResponse represents the response structure for starting TXT record validation.
+// A TXT record named Host with Value must be published.
+type StartDomainTXTValidationResponse struct {
+	Host  string `json:"host"`
+	Value string `json:"value"`
+}
+
+// SubmitDomainTXTValidationRequest represents the structure of the JSON payload for submitting TXT record validation.
+type SubmitDomainTXTValidationRequest struct {
+	Domain string `json:"domain"`
+}
+
+// SubmitDomainTXTValidationResponse represents the response structure for submitting TXT record validation.
+type SubmitDomainTXTValidationResponse struct {
+	OrderStatus string `json:"orderStatus"`
+	Message     string `json:"message"`
+	Status      string `json:"status"`
+}
+
+// StartDomainEmailValidationRequest represents the structure of the JSON payload for starting email validation.
+type StartDomainEmailValidationRequest struct {
+	Domain string `json:"domain"`
+}
+
+// StartDomainEmailValidationResponse represents the response structure for starting email validation,
+// listing the approver emails eligible for the domain.
+type StartDomainEmailValidationResponse struct {
+	Emails []string `json:"emails"`
+}
+
+// SubmitDomainEmailValidationRequest represents the structure of the JSON payload for submitting email validation.
+// Email must be one of the approver emails returned by StartDomainEmailValidation.
+type SubmitDomainEmailValidationRequest struct {
+	Domain string `json:"domain"`
+	Email  string `json:"email"`
+}
+
+// SubmitDomainEmailValidationResponse represents the response structure for submitting email validation.
+type SubmitDomainEmailValidationResponse struct {
+	OrderStatus string `json:"orderStatus"`
+	Message     string `json:"message"`
+	Status      string `json:"status"`
+}
+
 // GetDomainValidationStatusRequest represents the structure of the JSON payload for getting domain validation status.
 type GetDomainValidationStatusRequest struct {
 	Domain string `json:"domain"`
@@ -134,6 +239,7 @@ type DomainValidation struct {
 	DcvStatus      string `json:"dcvStatus"`
 	DcvOrderStatus string `json:"dcvOrderStatus"`
 	DcvMethod      string `json:"dcvMethod"`
+	ExpirationDate string `json:"expirationDate"`
 }
 
 // ListDomainValidationResponse represents the response structure for listing domain validations.
@@ -165,22 +271,119 @@ func (c *Client) GetDomainDetails(ctx context.Context, domainID int) (*DomainDet
 }
 
 // CreateDomain sends a request to create a new domain via the Sectigo API.
+// Use CreateDomainWithID to get the ID of the created domain.
 func (c *Client) CreateDomain(ctx context.Context, domainRequest DomainRequest) error {
+	_, err := c.createDomain(ctx, domainRequest)
+	return err
+}
+
+// CreateDomainWithID sends a request to create a new domain via the Sectigo API and returns the ID of the created
+// domain, read from the Location header of the response. When the header is missing or unparsable, the ID is looked
+// up with GetDomainByName, and an error matching ErrDomainIDUnknown is returned if that fails too: the domain
+// exists and the creation must not be retried.
+func (c *Client) CreateDomainWithID(ctx context.Context, domainRequest DomainRequest) (int, error) {
+	resp, err := c.createDomain(ctx, domainRequest)
+	if err != nil {
+		return 0, err
+	}
+
+	domainID, err := idFromLocation(resp.Header.Get("Location"))
+	if err == nil {
+		return domainID, nil
+	}
+
+	// The lookup error is not wrapped: it must not match ErrNotFound since the domain was created.
+	domainDetails, lookupErr := c.GetDomainByName(ctx, domainRequest.Name)
+	if lookupErr != nil {
+		return 0, fmt.Errorf("%w: domain %s: %w; looking it up by name: %v", ErrDomainIDUnknown, domainRequest.Name, err, lookupErr)
+	}
+	return domainDetails.ID, nil
+}
+
+// createDomain posts domainRequest to the domain creation endpoint and returns the response.
+func (c *Client) createDomain(ctx context.Context, domainRequest DomainRequest) (*http.Response, error) {
 	url := fmt.Sprintf("%s/api/domain/v1", c.BaseURL)
 	jsonPayload, err := json.Marshal(domainRequest)
 	if err != nil {
-		return fmt.Errorf("error marshalling JSON: %w", err)
+		return nil, fmt.Errorf("error marshalling JSON: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, _, err := c.sendRequest(ctx, req, http.StatusCreated)
+	return resp, err
+}
+
+// idFromLocation returns the ID ending the path of a Location header.
+func idFromLocation(location string) (int, error) {
+	if location == "" {
+		return 0, fmt.Errorf("missing Location header in response")
+	}
+	id, err := strconv.Atoi(path.Base(strings.TrimSuffix(location, "/")))
+	if err != nil {
+		return 0, fmt.Errorf("error parsing ID from Location header %q: %w", location, err)
+	}
+	return id, nil
+}
+
+// UpdateDomain sends a request to update the description, active state or delegations of a domain via the Sectigo API.
+func (c *Client) UpdateDomain(ctx context.Context, domainID int, updateRequest UpdateDomainRequest) error {
+	url := fmt.Sprintf("%s/api/domain/v1/%d", c.BaseURL, domainID)
+	jsonPayload, err := json.Marshal(updateRequest)
+	if err != nil {
+		return fmt.Errorf("error marshalling JSON: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		return fmt.Errorf("error creating request: %w", err)
 	}
 
-	_, _, err = c.sendRequest(ctx, req, http.StatusCreated)
+	_, _, err = c.sendRequest(ctx, req, http.StatusOK)
 	return err
 }
 
+// SuspendDomain sends a request to suspend a domain via the Sectigo API. Suspending a suspended domain succeeds.
+func (c *Client) SuspendDomain(ctx context.Context, domainID int) error {
+	return c.setDomainState(ctx, domainID, "suspend")
+}
+
+// ActivateDomain sends a request to activate a suspended domain via the Sectigo API. Activating an active domain succeeds.
+func (c *Client) ActivateDomain(ctx context.Context, domainID int) error {
+	return c.setDomainState(ctx, domainID, "activate")
+}
+
+// setDomainState sends a request to the suspend or activate endpoint of a domain.
+func (c *Client) setDomainState(ctx context.Context, domainID int, action string) error {
+	url := fmt.Sprintf("%s/api/domain/v1/%d/%s", c.BaseURL, domainID, action)
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	_, _, err = c.sendRequest(ctx, req, http.StatusOK)
+	return err
+}
+
+// GetDomainByName returns the details of the domain named name, found through ListDomain. Names are compared
+// case-insensitively, ignoring a trailing dot. It returns an error matching ErrNotFound when no domain has this name.
+func (c *Client) GetDomainByName(ctx context.Context, name string) (*DomainDetails, error) {
+	params := ListDomainParams{Size: defaultPageSize, Name: strings.TrimSuffix(name, ".")}
+	for domain, err := range c.DomainPager(params).All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if normalizeDNSName(domain.Name) == normalizeDNSName(name) {
+			return c.GetDomainDetails(ctx, domain.ID)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no domain named %s", ErrNotFound, name)
+}
+
 // DeleteDomain sends a request to delete a domain via the Sectigo API.
 func (c *Client) DeleteDomain(ctx context.Context, domainID int) error {
 	url := fmt.Sprintf("%s/api/domain/v1/%d", c.BaseURL, domainID)
@@ -276,88 +479,149 @@ func (c *Client) ListDomain(ctx context.Context, params ListDomainParams) (*List
 	return &listDomainResponse, nil
 }
 
-// ListAllDomain sends requests to list all domains by iterating through the results using the X-Total-Count header.
-func (c *Client) ListAllDomain(ctx context.Context, params ListDomainParams) ([]Domain, error) {
-	var allDomains []Domain
-	position := 0
-	size := 200
-
-	for {
-		params.Position = position
-		params.Size = size
-		listDomainResponse, err := c.ListDomain(ctx, params)
+// DomainPager returns a Pager over the domains matching params, using params.Size as the page size.
+func (c *Client) DomainPager(params ListDomainParams) *Pager[Domain] {
+	return NewPager(func(ctx context.Context, position, size int) ([]Domain, int, error) {
+		pageParams := params
+		pageParams.Position = position
+		pageParams.Size = size
+		listDomainResponse, err := c.ListDomain(ctx, pageParams)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
+		return listDomainResponse.Domains, listDomainResponse.TotalCount, nil
+	}, params.Size).WithConcurrency(c.PageConcurrency)
+}
 
-		allDomains = append(allDomains, listDomainResponse.Domains...)
+// ListAllDomain sends requests to list all domains by iterating through the results using the X-Total-Count header.
+func (c *Client) ListAllDomain(ctx context.Context, params ListDomainParams) ([]Domain, error) {
+	params.Size = defaultPageSize
+	return c.DomainPager(params).Collect(ctx)
+}
 
-		if len(listDomainResponse.Domains) < params.Size || position+params.Size >= listDomainResponse.TotalCount {
-			break
-		}
+// StartDomainCNameValidation sends a request to start CNAME validation for a domain via the Sectigo API.
+func (c *Client) StartDomainCNameValidation(ctx context.Context, request StartDomainCNameValidationRequest) (*StartDomainCNameValidationResponse, error) {
+	var validationResponse StartDomainCNameValidationResponse
+	if err := c.sendDCVRequest(ctx, "start", "cname", request, &validationResponse); err != nil {
+		return nil, err
+	}
+	return &validationResponse, nil
+}
 
-		position += params.Size
+// SubmitDomainCNameValidation sends a request to submit CNAME validation for a domain via the Sectigo API.
+func (c *Client) SubmitDomainCNameValidation(ctx context.Context, request SubmitDomainCNameValidationRequest) (*SubmitDomainCNameValidationResponse, error) {
+	var validationResponse SubmitDomainCNameValidationResponse
+	if err := c.sendDCVRequest(ctx, "submit", "cname", request, &validationResponse); err != nil {
+		return nil, err
 	}
+	return &validationResponse, nil
+}
 
-	return allDomains, nil
+// StartDomainHTTPValidation sends a request to start HTTP file validation for a domain via the Sectigo API.
+func (c *Client) StartDomainHTTPValidation(ctx context.Context, request StartDomainHTTPValidationRequest) (*StartDomainHTTPValidationResponse, error) {
+	var validationResponse StartDomainHTTPValidationResponse
+	if err := c.sendDCVRequest(ctx, "start", "http", request, &validationResponse); err != nil {
+		return nil, err
+	}
+	return &validationResponse, nil
 }
 
-// StartDomainCNameValidation sends a request to start CNAME validation for a domain via the Sectigo API.
-func (c *Client) StartDomainCNameValidation(ctx context.Context, request StartDomainCNameValidationRequest) (*StartDomainCNameValidationResponse, error) {
-	url := fmt.Sprintf("%s/api/dcv/v1/validation/start/domain/cname", c.BaseURL)
-	jsonPayload, err := json.Marshal(request)
-	if err != nil {
-		return nil, fmt.Errorf("error marshalling JSON: %w", err)
+// SubmitDomainHTTPValidation sends a request to submit HTTP file validation for a domain via the Sectigo API.
+func (c *Client) SubmitDomainHTTPValidation(ctx context.Context, request SubmitDomainHTTPValidationRequest) (*SubmitDomainHTTPValidationResponse, error) {
+	var validationResponse SubmitDomainHTTPValidationResponse
+	if err := c.sendDCVRequest(ctx, "submit", "http", request, &validationResponse); err != nil {
+		return nil, err
 	}
+	return &validationResponse, nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+// StartDomainHTTPSValidation sends a request to start HTTPS file validation for a domain via the Sectigo API.
+func (c *Client) StartDomainHTTPSValidation(ctx context.Context, request StartDomainHTTPValidationRequest) (*StartDomainHTTPValidationResponse, error) {
+	var validationResponse StartDomainHTTPValidationResponse
+	if err := c.sendDCVRequest(ctx, "start", "https", request, &validationResponse); err != nil {
+		return nil, err
 	}
+	return &validationResponse, nil
+}
 
-	_, body, err := c.sendRequest(ctx, req, http.StatusOK)
-	if err != nil {
+// SubmitDomainHTTPSValidation sends a request to submit HTTPS file validation for a domain via the Sectigo API.
+func (c *Client) SubmitDomainHTTPSValidation(ctx context.Context, request SubmitDomainHTTPValidationRequest) (*SubmitDomainHTTPValidationResponse, error) {
+	var validationResponse SubmitDomainHTTPValidationResponse
+	if err := c.sendDCVRequest(ctx, "submit", "https", request, &validationResponse); err != nil {
 		return nil, err
 	}
+	return &validationResponse, nil
+}
 
-	var validationResponse StartDomainCNameValidationResponse
-	err = json.Unmarshal(body, &validationResponse)
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling response: %w", err)
+// StartDomainTXTValidation sends a request to start TXT record validation for a domain via the Sectigo API.
+func (c *Client) StartDomainTXTValidation(ctx context.Context, request StartDomainTXTValidationRequest) (*StartDomainTXTValidationResponse, error) {
+	var validationResponse StartDomainTXTValidationResponse
+	if err := c.sendDCVRequest(ctx, "start", "txt", request, &validationResponse); err != nil {
+		return nil, err
 	}
+	return &validationResponse, nil
+}
 
+// SubmitDomainTXTValidation sends a request to submit TXT record validation for a domain via the Sectigo API.
+func (c *Client) SubmitDomainTXTValidation(ctx context.Context, request SubmitDomainTXTValidationRequest) (*SubmitDomainTXTValidationResponse, error) {
+	var validationResponse SubmitDomainTXTValidationResponse
+	if err := c.sendDCVRequest(ctx, "submit", "txt", request, &validationResponse); err != nil {
+		return nil, err
+	}
 	return &validationResponse, nil
 }
 
-// SubmitDomainCNameValidation sends a request to submit CNAME validation for a domain via the Sectigo API.
-func (c *Client) SubmitDomainCNameValidation(ctx context.Context, request SubmitDomainCNameValidationRequest) (*SubmitDomainCNameValidationResponse, error) {
-	url := fmt.Sprintf("%s/api/dcv/v1/validation/submit/domain/cname", c.BaseURL)
+// StartDomainEmailValidation sends a request to start email validation for a domain via the Sectigo API,
+// returning the approver emails eligible for the domain.
+func (c *Client) StartDomainEmailValidation(ctx context.Context, request StartDomainEmailValidationRequest) (*StartDomainEmailValidationResponse, error) {
+	var validationResponse StartDomainEmailValidationResponse
+	if err := c.sendDCVRequest(ctx, "start", "email", request, &validationResponse); err != nil {
+		return nil, err
+	}
+	return &validationResponse, nil
+}
+
+// SubmitDomainEmailValidation sends a request to submit email validation for a domain via the Sectigo API,
+// sending the validation email to the chosen approver.
+func (c *Client) SubmitDomainEmailValidation(ctx context.Context, request SubmitDomainEmailValidationRequest) (*SubmitDomainEmailValidationResponse, error) {
+	var validationResponse SubmitDomainEmailValidationResponse
+	if err := c.sendDCVRequest(ctx, "submit", "email", request, &validationResponse); err != nil {
+		return nil, err
+	}
+	return &validationResponse, nil
+}
+
+// sendDCVRequest posts request to the start or submit endpoint of a domain control validation method
+// and decodes the response into validationResponse.
+func (c *Client) sendDCVRequest(ctx context.Context, action, method string, request, validationResponse any) error {
+	url := fmt.Sprintf("%s/api/dcv/v1/validation/%s/domain/%s", c.BaseURL, action, method)
 	jsonPayload, err := json.Marshal(request)
 	if err != nil {
-		return nil, fmt.Errorf("error marshalling JSON: %w", err)
+		return fmt.Errorf("error marshalling JSON: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return fmt.Errorf("error creating request: %w", err)
 	}
 
 	_, body, err := c.sendRequest(ctx, req, http.StatusOK)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var validationResponse SubmitDomainCNameValidationResponse
-	err = json.Unmarshal(body, &validationResponse)
+	err = json.Unmarshal(body, validationResponse)
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling response: %w", err)
+		return fmt.Errorf("error unmarshalling response: %w", err)
 	}
 
-	return &validationResponse, nil
+	return nil
 }
 
 // GetDomainValidationStatus sends a request to get the validation status for a domain via the Sectigo API.
 func (c *Client) GetDomainValidationStatus(ctx context.Context, request GetDomainValidationStatusRequest) (*GetDomainValidationStatusResponse, error) {
+	// The status lookup is a read-only POST and is safe to retry.
+	ctx = WithReplayableRequest(ctx)
 	url := fmt.Sprintf("%s/api/dcv/v2/validation/status", c.BaseURL)
 	jsonPayload, err := json.Marshal(request)
 	if err != nil {
@@ -383,7 +647,29 @@ func (c *Client) GetDomainValidationStatus(ctx context.Context, request GetDomai
 	return &validationResponse, nil
 }
 
+// WaitForDomainValidation polls the validation status of domain until it is validated. It returns an error
+// matching ErrDomainValidationFailed when the status becomes expired or failed, and a *WaitTimeoutError when
+// options.Timeout, options.MaxAttempts or ctx run out first. Any other status is considered pending.
+func (c *Client) WaitForDomainValidation(ctx context.Context, domain string, options WaitOptions) (*GetDomainValidationStatusResponse, error) {
+	return Wait(ctx, func(ctx context.Context) (*GetDomainValidationStatusResponse, string, bool, error) {
+		response, err := c.GetDomainValidationStatus(ctx, GetDomainValidationStatusRequest{Domain: domain})
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		switch strings.ToUpper(response.Status) {
+		case DomainValidationStatusValidated:
+			return response, response.Status, true, nil
+		case DomainValidationStatusExpired, DomainValidationStatusFailed:
+			return nil, response.Status, false, fmt.Errorf("%w: domain %s has status %s", ErrDomainValidationFailed, domain, response.Status)
+		}
+		return response, response.Status, false, nil
+	}, options)
+}
+
 // CheckDomainValidationStatus checks the domain validation status with retries.
+//
+// Deprecated: use WaitForDomainValidation, which supports backoff, timeouts and progress reporting.
 func (c *Client) CheckDomainValidationStatus(ctx context.Context, domain string, maxRetries int, retryInterval time.Duration) error {
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		response, err := c.GetDomainValidationStatus(ctx, GetDomainValidationStatusRequest{Domain: domain})
@@ -458,28 +744,22 @@ func (c *Client) ListDomainValidation(ctx context.Context, params ListDomainVali
 	return &listDomainValidationResponse, nil
 }
 
-// ListAllDomainValidation sends requests to list all domains by iterating through the results using the X-Total-Count header.
-func (c *Client) ListAllDomainValidation(ctx context.Context, params ListDomainValidationParams) ([]DomainValidation, error) {
-	var allDomainsValidation []DomainValidation
-	position := 0
-	size := 200
-
-	for {
-		params.Position = position
-		params.Size = size
-		listDomainValidationResponse, err := c.ListDomainValidation(ctx, params)
+// DomainValidationPager returns a Pager over the domain validations matching params, using params.Size as the page size.
+func (c *Client) DomainValidationPager(params ListDomainValidationParams) *Pager[DomainValidation] {
+	return NewPager(func(ctx context.Context, position, size int) ([]DomainValidation, int, error) {
+		pageParams := params
+		pageParams.Position = position
+		pageParams.Size = size
+		listDomainValidationResponse, err := c.ListDomainValidation(ctx, pageParams)
 		if err != nil {
-			return nil, err
-		}
-
-		allDomainsValidation = append(allDomainsValidation, listDomainValidationResponse.Domains...)
-
-		if len(listDomainValidationResponse.Domains) < params.Size || position+params.Size >= listDomainValidationResponse.TotalCount {
-			break
+			return nil, 0, err
 		}
+		return listDomainValidationResponse.Domains, listDomainValidationResponse.TotalCount, nil
+	}, params.Size).WithConcurrency(c.PageConcurrency)
+}
 
-		position += params.Size
-	}
-
-	return allDomainsValidation, nil
+// ListAllDomainValidation sends requests to list all domains by iterating through the results using the X-Total-Count header.
+func (c *Client) ListAllDomainValidation(ctx context.Context, params ListDomainValidationParams) ([]DomainValidation, error) {
+	params.Size = defaultPageSize
+	return c.DomainValidationPager(params).Collect(ctx)
 }
@@ -3,6 +3,7 @@ package sectigo
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
 	"time"
@@ -98,6 +99,95 @@ func TestCreateDomain(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCreateDomainWithID(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/domain/v1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.Header().Set("Location", "https://cert-manager.com/api/domain/v1/42")
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	domainID, err := client.CreateDomainWithID(context.Background(), DomainRequest{Name: "example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, 42, domainID)
+}
+
+func TestCreateDomainWithID_MissingLocation(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/domain/v1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			w.WriteHeader(http.StatusCreated)
+		case "GET":
+			assert.Equal(t, "example.com", r.URL.Query().Get("name"))
+			w.Header().Set("X-Total-Count", "1")
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode([]Domain{{ID: 42, Name: "example.com"}})
+		}
+	})
+	mockClient.Mux.HandleFunc("/api/domain/v1/42", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(DomainDetails{ID: 42, Name: "example.com"})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	domainID, err := client.CreateDomainWithID(context.Background(), DomainRequest{Name: "example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, 42, domainID)
+}
+
+func TestCreateDomainWithID_IDUnknown(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/domain/v1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			w.Header().Set("Location", "/api/domain/v1/abc")
+			w.WriteHeader(http.StatusCreated)
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode([]Domain{})
+		}
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	_, err := client.CreateDomainWithID(context.Background(), DomainRequest{Name: "example.com"})
+	assert.True(t, errors.Is(err, ErrDomainIDUnknown))
+	assert.False(t, errors.Is(err, ErrNotFound))
+	assert.Contains(t, err.Error(), "no domain named example.com")
+	assert.Contains(t, err.Error(), `error parsing ID from Location header "/api/domain/v1/abc"`)
+}
+
 func TestCreateDomain_Error(t *testing.T) {
 	mockClient := NewMockClient()
 	defer mockClient.Close()
@@ -128,6 +218,117 @@ func TestCreateDomain_Error(t *testing.T) {
 	assert.Contains(t, err.Error(), "Invalid domain name")
 }
 
+func TestUpdateDomain(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/domain/v1/1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]any{"active": false, "delegations": []any{}}, body)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	active := false
+	delegations := []DelegationRequest{}
+	err := client.UpdateDomain(context.Background(), 1, UpdateDomainRequest{Active: &active, Delegations: &delegations})
+	assert.NoError(t, err)
+}
+
+func TestSuspendAndActivateDomain(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	var paths []string
+	mockClient.Mux.HandleFunc("/api/domain/v1/1/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	assert.NoError(t, client.SuspendDomain(ctx, 1))
+	assert.NoError(t, client.ActivateDomain(ctx, 1))
+	assert.Equal(t, []string{"/api/domain/v1/1/suspend", "/api/domain/v1/1/activate"}, paths)
+}
+
+func TestGetDomainByName(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/domain/v1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "example.com", r.URL.Query().Get("name"))
+		w.Header().Set("X-Total-Count", "2")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode([]Domain{{ID: 1, Name: "sub.example.com"}, {ID: 2, Name: "Example.com"}})
+	})
+	mockClient.Mux.HandleFunc("/api/domain/v1/2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(DomainDetails{ID: 2, Name: "Example.com", Description: "managed"})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	domainDetails, err := client.GetDomainByName(ctx, "example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, domainDetails.ID)
+	assert.Equal(t, "managed", domainDetails.Description)
+
+	_, err = client.GetDomainByName(ctx, "example.com.")
+	assert.NoError(t, err)
+}
+
+func TestGetDomainByName_NotFound(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/domain/v1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Total-Count", "1")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode([]Domain{{ID: 1, Name: "sub.example.com"}})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	_, err := client.GetDomainByName(context.Background(), "example.com")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Contains(t, err.Error(), "no domain named example.com")
+}
+
 func TestDeleteDomain(t *testing.T) {
 	mockClient := NewMockClient()
 	defer mockClient.Close()
@@ -347,6 +548,230 @@ func TestSubmitDomainCNameValidation(t *testing.T) {
 	assert.Equal(t, "valid", response.OrderStatus)
 }
 
+func TestStartDomainHTTPValidation(t *testing.T) {
+	for _, scheme := range []string{"http", "https"} {
+		t.Run(scheme, func(t *testing.T) {
+			mockClient := NewMockClient()
+			defer mockClient.Close()
+
+			mockClient.Mux.HandleFunc("/api/dcv/v1/validation/start/domain/"+scheme, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "POST", r.Method)
+				var request StartDomainHTTPValidationRequest
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+				assert.Equal(t, "example.com", request.Domain)
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(StartDomainHTTPValidationResponse{
+					URL:        scheme + "://example.com/.well-known/pki-validation/ABCDEF.txt",
+					FirstLine:  "0123456789abcdef",
+					SecondLine: "sectigo.com",
+				})
+			})
+
+			client := NewClient(Config{
+				URL:      mockClient.Server.URL,
+				Username: "test",
+				Customer: "test",
+				Password: "test",
+				Debug:    false,
+			})
+			client.Client = mockClient.Client
+
+			start := client.StartDomainHTTPValidation
+			if scheme == "https" {
+				start = client.StartDomainHTTPSValidation
+			}
+
+			ctx := context.Background()
+			response, err := start(ctx, StartDomainHTTPValidationRequest{Domain: "example.com"})
+			assert.NoError(t, err)
+			assert.Equal(t, scheme+"://example.com/.well-known/pki-validation/ABCDEF.txt", response.URL)
+			assert.Equal(t, "0123456789abcdef\nsectigo.com", response.FileContents())
+		})
+	}
+}
+
+func TestSubmitDomainHTTPValidation(t *testing.T) {
+	for _, scheme := range []string{"http", "https"} {
+		t.Run(scheme, func(t *testing.T) {
+			mockClient := NewMockClient()
+			defer mockClient.Close()
+
+			mockClient.Mux.HandleFunc("/api/dcv/v1/validation/submit/domain/"+scheme, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "POST", r.Method)
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(SubmitDomainHTTPValidationResponse{
+					OrderStatus: "SUBMITTED",
+					Message:     "DCV request submitted",
+					Status:      "NOT_VALIDATED",
+				})
+			})
+
+			client := NewClient(Config{
+				URL:      mockClient.Server.URL,
+				Username: "test",
+				Customer: "test",
+				Password: "test",
+				Debug:    false,
+			})
+			client.Client = mockClient.Client
+
+			submit := client.SubmitDomainHTTPValidation
+			if scheme == "https" {
+				submit = client.SubmitDomainHTTPSValidation
+			}
+
+			ctx := context.Background()
+			response, err := submit(ctx, SubmitDomainHTTPValidationRequest{Domain: "example.com"})
+			assert.NoError(t, err)
+			assert.Equal(t, "SUBMITTED", response.OrderStatus)
+			assert.Equal(t, "NOT_VALIDATED", response.Status)
+		})
+	}
+}
+
+func TestStartDomainTXTValidation(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/dcv/v1/validation/start/domain/txt", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(StartDomainTXTValidationResponse{
+			Host:  "_dnsauth.example.com.",
+			Value: "0123456789abcdef",
+		})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	response, err := client.StartDomainTXTValidation(ctx, StartDomainTXTValidationRequest{Domain: "example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "_dnsauth.example.com.", response.Host)
+	assert.Equal(t, "0123456789abcdef", response.Value)
+}
+
+func TestSubmitDomainTXTValidation(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/dcv/v1/validation/submit/domain/txt", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(SubmitDomainTXTValidationResponse{
+			OrderStatus: "SUBMITTED",
+			Message:     "DCV request submitted",
+			Status:      "VALIDATED",
+		})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	response, err := client.SubmitDomainTXTValidation(ctx, SubmitDomainTXTValidationRequest{Domain: "example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "VALIDATED", response.Status)
+}
+
+func TestStartDomainEmailValidation(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/dcv/v1/validation/start/domain/email", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(StartDomainEmailValidationResponse{
+			Emails: []string{"admin@example.com", "hostmaster@example.com"},
+		})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	response, err := client.StartDomainEmailValidation(ctx, StartDomainEmailValidationRequest{Domain: "example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"admin@example.com", "hostmaster@example.com"}, response.Emails)
+}
+
+func TestSubmitDomainEmailValidation(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/dcv/v1/validation/submit/domain/email", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		var request SubmitDomainEmailValidationRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "example.com", request.Domain)
+		assert.Equal(t, "admin@example.com", request.Email)
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(SubmitDomainEmailValidationResponse{
+			OrderStatus: "SUBMITTED",
+			Message:     "Email sent",
+			Status:      "NOT_VALIDATED",
+		})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	response, err := client.SubmitDomainEmailValidation(ctx, SubmitDomainEmailValidationRequest{Domain: "example.com", Email: "admin@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "SUBMITTED", response.OrderStatus)
+}
+
+func TestSubmitDomainEmailValidation_Error(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/dcv/v1/validation/submit/domain/email", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-3,"description":"Email is not an approver email"}`)) //nolint:errcheck
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	_, err := client.SubmitDomainEmailValidation(ctx, SubmitDomainEmailValidationRequest{Domain: "example.com", Email: "user@example.com"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "400")
+	assert.Contains(t, err.Error(), "Email is not an approver email")
+}
+
 func TestGetDomainValidationStatus(t *testing.T) {
 	mockClient := NewMockClient()
 	defer mockClient.Close()
@@ -463,6 +888,93 @@ func TestCheckDomainValidationStatus_MaxRetriesReached(t *testing.T) {
 	assert.Contains(t, err.Error(), "max retries reached")
 }
 
+func TestWaitForDomainValidation(t *testing.T) {
+	for _, tc := range []struct {
+		status  string
+		wantErr error
+	}{
+		{status: "VALIDATED"},
+		{status: "EXPIRED", wantErr: ErrDomainValidationFailed},
+		{status: "failed", wantErr: ErrDomainValidationFailed},
+		{status: "NOT_VALIDATED", wantErr: ErrWaitTimeout},
+		{status: "PENDING", wantErr: ErrWaitTimeout},
+	} {
+		t.Run(tc.status, func(t *testing.T) {
+			mockClient := NewMockClient()
+			defer mockClient.Close()
+
+			mockClient.Mux.HandleFunc("/api/dcv/v2/validation/status", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(GetDomainValidationStatusResponse{Status: tc.status})
+			})
+
+			client := NewClient(Config{
+				URL:      mockClient.Server.URL,
+				Username: "test",
+				Customer: "test",
+				Password: "test",
+				Debug:    false,
+			})
+			client.Client = mockClient.Client
+
+			response, err := client.WaitForDomainValidation(context.Background(), "example.com", WaitOptions{
+				Backoff:     ConstantBackoff(time.Millisecond),
+				MaxAttempts: 2,
+			})
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.status, response.Status)
+				return
+			}
+			assert.True(t, errors.Is(err, tc.wantErr))
+		})
+	}
+}
+
+func TestCheckDomainValidationStatus_Expired(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/dcv/v2/validation/status", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(GetDomainValidationStatusResponse{Status: "EXPIRED"})
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	// Unlike WaitForDomainValidation, only NOT_VALIDATED is retried.
+	err := client.CheckDomainValidationStatus(context.Background(), "example.com", 3, 1*time.Millisecond)
+	assert.NoError(t, err)
+}
+
+func TestCheckDomainValidationStatus_NoRetries(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/dcv/v2/validation/status", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	err := client.CheckDomainValidationStatus(context.Background(), "example.com", 0, 1*time.Millisecond)
+	assert.EqualError(t, err, "max retries reached, domain is still not validated")
+}
+
 func TestListDomainValidation(t *testing.T) {
 	mockClient := NewMockClient()
 	defer mockClient.Close()
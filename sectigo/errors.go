@@ -0,0 +1,81 @@
+package sectigo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors matched by APIError through errors.Is.
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServerError  = errors.New("server error")
+)
+
+// APIError is returned when the Sectigo API answers with an unexpected status code.
+type APIError struct {
+	StatusCode  int
+	Code        int
+	Description string
+	Method      string
+	URL         string
+	Body        []byte
+}
+
+// newAPIError builds an APIError from a failed response, decoding the Sectigo error code and description when present.
+func newAPIError(req *http.Request, statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		Body:       body,
+	}
+
+	var errResponse struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	}
+	if err := json.Unmarshal(body, &errResponse); err == nil {
+		apiErr.Code = errResponse.Code
+		apiErr.Description = errResponse.Description
+	}
+
+	return apiErr
+}
+
+// Error implements the error interface. The response body is truncated to 500 bytes, the full body is kept in Body.
+func (e *APIError) Error() string {
+	bodyStr := string(e.Body)
+	if len(bodyStr) > 500 {
+		bodyStr = bodyStr[:500] + "... (truncated)"
+	}
+	return fmt.Sprintf("failed request, status code: %d, response: %s", e.StatusCode, bodyStr)
+}
+
+// Is reports whether the error matches one of the sentinel errors for its status code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
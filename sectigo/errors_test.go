@@ -0,0 +1,106 @@
+package sectigo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		sentinel   error
+	}{
+		{http.StatusBadRequest, ErrBadRequest},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusBadGateway, ErrServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			err := error(&APIError{StatusCode: tt.statusCode})
+			assert.True(t, errors.Is(err, tt.sentinel))
+			if tt.sentinel != ErrNotFound {
+				assert.False(t, errors.Is(err, ErrNotFound))
+			}
+		})
+	}
+}
+
+func TestAPIError_Error(t *testing.T) {
+	longBody := strings.Repeat("a", 600)
+	err := &APIError{StatusCode: http.StatusBadRequest, Body: []byte(longBody)}
+
+	assert.Contains(t, err.Error(), "status code: 400")
+	assert.Contains(t, err.Error(), "... (truncated)")
+	assert.Equal(t, 600, len(err.Body))
+}
+
+func TestSendRequest_APIError(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/1638", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":-1011,"description":"Certificate not found"}`)) //nolint:errcheck
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	_, err := client.GetSSLDetails(ctx, 1638)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, -1011, apiErr.Code)
+	assert.Equal(t, "Certificate not found", apiErr.Description)
+	assert.Equal(t, "GET", apiErr.Method)
+	assert.Equal(t, mockClient.Server.URL+"/api/ssl/v1/1638", apiErr.URL)
+}
+
+func TestSendRequest_APIErrorWithoutJSON(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`Too Many Requests`)) //nolint:errcheck
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	ctx := context.Background()
+	req, _ := http.NewRequestWithContext(ctx, "GET", mockClient.Server.URL+"/test", nil)
+	_, _, err := client.sendRequest(ctx, req, http.StatusOK)
+	assert.True(t, errors.Is(err, ErrRateLimited))
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 0, apiErr.Code)
+	assert.Equal(t, "Too Many Requests", string(apiErr.Body))
+}
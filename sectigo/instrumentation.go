@@ -0,0 +1,116 @@
+package sectigo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Instrumentation observes every attempt of the API calls made by a Client.
+// It is used to plug tracing and metrics into the request path, see the otelsectigo package.
+type Instrumentation interface {
+	// StartRequest is called before each attempt. The returned context is used for the attempt and the
+	// returned function is called once the attempt completed.
+	StartRequest(ctx context.Context, info RequestInfo) (context.Context, func(RequestResult))
+}
+
+// RequestInfo describes an API call attempt.
+type RequestInfo struct {
+	// Operation is the name of the Client method making the call, e.g. "ListSSL".
+	Operation string
+	Method    string
+	URL       string
+	// Attempt is the attempt number starting at 1, greater values are retries.
+	Attempt int
+	// Position is the position of the requested page for paginated calls, -1 otherwise.
+	Position int
+}
+
+// RequestResult describes the outcome of an API call attempt.
+type RequestResult struct {
+	// StatusCode is the HTTP status code, 0 when no response was received.
+	StatusCode int
+	// ErrorCode is the Sectigo error code of an *APIError, 0 otherwise.
+	ErrorCode int
+	Err       error
+	Duration  time.Duration
+}
+
+// WithOperationName returns a context naming the operation reported to the Instrumentation for calls
+// made with it, overriding the name of the calling Client method.
+func WithOperationName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationNameKey{}, name)
+}
+
+type operationNameKey struct{}
+
+// instrumentedRequest performs a single attempt of req, reporting it to the Instrumentation if any.
+func (c *Client) instrumentedRequest(req *http.Request, expectedStatus int, operation string, attempt int) (*http.Response, []byte, error) {
+	if c.Instrumentation == nil {
+		return c.doRequest(req, expectedStatus)
+	}
+
+	ctx, end := c.Instrumentation.StartRequest(req.Context(), RequestInfo{
+		Operation: operation,
+		Method:    req.Method,
+		URL:       req.URL.String(),
+		Attempt:   attempt,
+		Position:  pagePosition(req),
+	})
+	start := time.Now()
+	resp, body, err := c.doRequest(req.WithContext(ctx), expectedStatus)
+
+	result := RequestResult{Err: err, Duration: time.Since(start)}
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		result.ErrorCode = apiErr.Code
+	}
+	end(result)
+
+	return resp, body, err
+}
+
+// operationName returns the operation name set with WithOperationName or, by default, the name of the
+// exported Client method found in the call stack.
+func operationName(ctx context.Context) string {
+	if name, ok := ctx.Value(operationNameKey{}).(string); ok {
+		return name
+	}
+
+	pc := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pc[:runtime.Callers(3, pc)])
+	for {
+		frame, more := frames.Next()
+		if _, method, ok := strings.Cut(frame.Function, ".(*Client)."); ok && isExported(method) {
+			return method
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// isExported reports whether name starts with an upper case letter.
+func isExported(name string) bool {
+	for _, r := range name {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+// pagePosition returns the position query parameter of req, or -1 when it is not paginated.
+func pagePosition(req *http.Request) int {
+	position, err := strconv.Atoi(req.URL.Query().Get("position"))
+	if err != nil {
+		return -1
+	}
+	return position
+}
@@ -0,0 +1,132 @@
+package sectigo
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingInstrumentation records the attempts reported to it.
+type recordingInstrumentation struct {
+	mu      sync.Mutex
+	infos   []RequestInfo
+	results []RequestResult
+}
+
+func (r *recordingInstrumentation) StartRequest(ctx context.Context, info RequestInfo) (context.Context, func(RequestResult)) {
+	r.mu.Lock()
+	r.infos = append(r.infos, info)
+	r.mu.Unlock()
+
+	return ctx, func(result RequestResult) {
+		r.mu.Lock()
+		r.results = append(r.results, result)
+		r.mu.Unlock()
+	}
+}
+
+func TestInstrumentation_ListSSL(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Total-Count", "0")
+		w.Write([]byte(`[]`)) //nolint:errcheck
+	})
+
+	instrumentation := &recordingInstrumentation{}
+	client := NewClient(Config{
+		URL:             mockClient.Server.URL,
+		Username:        "test",
+		Customer:        "test",
+		Password:        "test",
+		Instrumentation: instrumentation,
+	})
+
+	_, err := client.ListSSL(context.Background(), ListSSLParams{Position: 10, Size: 5})
+	assert.NoError(t, err)
+
+	if assert.Len(t, instrumentation.infos, 1) {
+		info := instrumentation.infos[0]
+		assert.Equal(t, "ListSSL", info.Operation)
+		assert.Equal(t, http.MethodGet, info.Method)
+		assert.Equal(t, 1, info.Attempt)
+		assert.Equal(t, 10, info.Position)
+	}
+	if assert.Len(t, instrumentation.results, 1) {
+		assert.Equal(t, http.StatusOK, instrumentation.results[0].StatusCode)
+		assert.NoError(t, instrumentation.results[0].Err)
+	}
+}
+
+func TestInstrumentation_ErrorAndRetries(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	calls := 0
+	mockClient.Mux.HandleFunc("/api/ssl/v1/123", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-1400,"description":"Certificate not found"}`)) //nolint:errcheck
+	})
+
+	instrumentation := &recordingInstrumentation{}
+	client := NewClient(Config{
+		URL:             mockClient.Server.URL,
+		Username:        "test",
+		Customer:        "test",
+		Password:        "test",
+		Retry:           RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+		Instrumentation: instrumentation,
+	})
+
+	_, err := client.GetSSLDetails(context.Background(), 123)
+	assert.Error(t, err)
+
+	if assert.Len(t, instrumentation.infos, 2) {
+		assert.Equal(t, "GetSSLDetails", instrumentation.infos[0].Operation)
+		assert.Equal(t, -1, instrumentation.infos[0].Position)
+		assert.Equal(t, 2, instrumentation.infos[1].Attempt)
+	}
+	if assert.Len(t, instrumentation.results, 2) {
+		assert.Equal(t, http.StatusServiceUnavailable, instrumentation.results[0].StatusCode)
+		assert.Equal(t, http.StatusBadRequest, instrumentation.results[1].StatusCode)
+		assert.Equal(t, -1400, instrumentation.results[1].ErrorCode)
+		assert.Error(t, instrumentation.results[1].Err)
+	}
+}
+
+func TestInstrumentation_WithOperationName(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	instrumentation := &recordingInstrumentation{}
+	client := NewClient(Config{
+		URL:             mockClient.Server.URL,
+		Username:        "test",
+		Customer:        "test",
+		Password:        "test",
+		Instrumentation: instrumentation,
+	})
+
+	ctx := WithOperationName(context.Background(), "Custom")
+	req, _ := http.NewRequestWithContext(ctx, "GET", mockClient.Server.URL+"/test", nil)
+	_, _, err := client.sendRequest(ctx, req, http.StatusOK)
+	assert.NoError(t, err)
+
+	if assert.Len(t, instrumentation.infos, 1) {
+		assert.Equal(t, "Custom", instrumentation.infos[0].Operation)
+	}
+}
@@ -0,0 +1,171 @@
+package sectigo
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// defaultLogBodyLimit is the maximum number of body bytes logged when Config.LogBodyLimit is not set.
+const defaultLogBodyLimit = 4096
+
+// defaultRedactedBodyFields lists the JSON body fields redacted when Config.LogRedactFields is not set.
+var defaultRedactedBodyFields = []string{"password", "csr", "macKey"}
+
+// redactedHeaders lists the headers never written to the logs.
+var redactedHeaders = map[string]bool{
+	"Password":      true,
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// redactedValue replaces sensitive values in the logs.
+const redactedValue = "REDACTED"
+
+// requestLogger writes redacted debug logs for the requests and responses of the client.
+type requestLogger struct {
+	logger       *slog.Logger
+	redactFields map[string]bool
+	bodyLimit    int
+}
+
+// newRequestLogger creates a requestLogger from the configuration, or returns nil when logging is disabled.
+// Debug without a Logger logs to stderr at debug level.
+func newRequestLogger(config Config) *requestLogger {
+	logger := config.Logger
+	if logger == nil {
+		if !config.Debug {
+			return nil
+		}
+		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	fields := config.LogRedactFields
+	if fields == nil {
+		fields = defaultRedactedBodyFields
+	}
+	redactFields := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		redactFields[strings.ToLower(field)] = true
+	}
+
+	bodyLimit := config.LogBodyLimit
+	if bodyLimit == 0 {
+		bodyLimit = defaultLogBodyLimit
+	}
+
+	return &requestLogger{
+		logger:       logger,
+		redactFields: redactFields,
+		bodyLimit:    bodyLimit,
+	}
+}
+
+// enabled reports whether debug logs are written for ctx.
+func (l *requestLogger) enabled(ctx context.Context) bool {
+	return l != nil && l.logger.Enabled(ctx, slog.LevelDebug)
+}
+
+// logRequest logs req with redacted headers and body and returns the correlation ID attached to its logs.
+func (l *requestLogger) logRequest(req *http.Request) string {
+	requestID := newRequestID()
+
+	attrs := []any{
+		slog.String("request_id", requestID),
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Any("headers", l.redactHeaders(req.Header)),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, _ := io.ReadAll(req.Body)
+		req.Body = io.NopCloser(bytes.NewBuffer(body))
+		attrs = append(attrs, slog.String("body", l.formatBody(body)))
+	}
+
+	l.logger.DebugContext(req.Context(), "sectigo request", attrs...)
+	return requestID
+}
+
+// logResponse logs resp with the correlation ID of its request.
+func (l *requestLogger) logResponse(req *http.Request, requestID string, resp *http.Response) {
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewBuffer(body))
+
+	l.logger.DebugContext(req.Context(), "sectigo response",
+		slog.String("request_id", requestID),
+		slog.Int("status", resp.StatusCode),
+		slog.Any("headers", l.redactHeaders(resp.Header)),
+		slog.String("body", l.formatBody(body)),
+	)
+}
+
+// logError logs a transport error with the correlation ID of its request.
+func (l *requestLogger) logError(req *http.Request, requestID string, err error) {
+	l.logger.DebugContext(req.Context(), "sectigo request failed",
+		slog.String("request_id", requestID),
+		slog.String("error", err.Error()),
+	)
+}
+
+// redactHeaders returns a copy of headers with credential values replaced.
+func (l *requestLogger) redactHeaders(headers http.Header) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for name, values := range headers {
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			redacted[name] = redactedValue
+			continue
+		}
+		redacted[name] = strings.Join(values, ", ")
+	}
+	return redacted
+}
+
+// formatBody redacts the configured fields of a JSON body and truncates it to the body limit.
+func (l *requestLogger) formatBody(body []byte) string {
+	var decoded any
+	if len(l.redactFields) > 0 && json.Unmarshal(body, &decoded) == nil {
+		if redacted, err := json.Marshal(l.redactJSON(decoded)); err == nil {
+			body = redacted
+		}
+	}
+
+	if l.bodyLimit > 0 && len(body) > l.bodyLimit {
+		return string(body[:l.bodyLimit]) + "... (truncated)"
+	}
+	return string(body)
+}
+
+// redactJSON walks a decoded JSON value and replaces the values of the configured fields.
+func (l *requestLogger) redactJSON(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if l.redactFields[strings.ToLower(key)] {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = l.redactJSON(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = l.redactJSON(item)
+		}
+	}
+	return value
+}
+
+// newRequestID returns a random correlation ID.
+func newRequestID() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,125 @@
+package sectigo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// decodeLogRecords parses the JSON log lines written to buf.
+func decodeLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestRequestLogger_Redaction(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1/enroll", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("password"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"sslId":1740,"renewId":"renew-1740"}`)) //nolint:errcheck
+	})
+
+	var buf bytes.Buffer
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "secret",
+		Logger:   slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+
+	_, err := client.EnrollSSL(context.Background(), EnrollSSLRequest{OrgId: 1, CertType: 17, Term: 365, CSR: "MIIBsensitive"})
+	assert.NoError(t, err)
+
+	assert.NotContains(t, buf.String(), "secret")
+	assert.NotContains(t, buf.String(), "MIIBsensitive")
+
+	records := decodeLogRecords(t, &buf)
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, "sectigo request", records[0]["msg"])
+	assert.Equal(t, "sectigo response", records[1]["msg"])
+	assert.NotEmpty(t, records[0]["request_id"])
+	assert.Equal(t, records[0]["request_id"], records[1]["request_id"])
+	assert.Equal(t, "REDACTED", records[0]["headers"].(map[string]any)["Password"])
+	assert.Contains(t, records[0]["body"], `"csr":"REDACTED"`)
+	assert.Contains(t, records[1]["body"], `"sslId":1740`)
+}
+
+func TestRequestLogger_CustomFieldsAndBodyLimit(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/organization/v1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id":1,"name":"` + strings.Repeat("a", 100) + `"}]`)) //nolint:errcheck
+	})
+
+	var buf bytes.Buffer
+	client := NewClient(Config{
+		URL:             mockClient.Server.URL,
+		Username:        "test",
+		Customer:        "test",
+		Password:        "test",
+		Logger:          slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		LogRedactFields: []string{"id"},
+		LogBodyLimit:    32,
+	})
+
+	_, err := client.ListOrganization(context.Background())
+	assert.NoError(t, err)
+
+	records := decodeLogRecords(t, &buf)
+	body := records[1]["body"].(string)
+	assert.True(t, strings.HasPrefix(body, `[{"id":"REDACTED"`))
+	assert.True(t, strings.HasSuffix(body, "... (truncated)"))
+}
+
+func TestRequestLogger_DisabledLevel(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/organization/v1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[]`)) //nolint:errcheck
+	})
+
+	var buf bytes.Buffer
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Logger:   slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})),
+	})
+
+	_, err := client.ListOrganization(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
+
+func TestNewRequestLogger(t *testing.T) {
+	assert.Nil(t, newRequestLogger(Config{}))
+
+	logger := newRequestLogger(Config{Debug: true})
+	assert.NotNil(t, logger)
+	assert.True(t, logger.enabled(context.Background()))
+	assert.Equal(t, defaultLogBodyLimit, logger.bodyLimit)
+	assert.True(t, logger.redactFields["csr"])
+	assert.True(t, logger.redactFields["mackey"])
+}
@@ -0,0 +1,164 @@
+package sectigo
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures the HTTP stack of a client created with NewClientWithOptions.
+type Option func(*clientOptions)
+
+// Middleware wraps the transport used to send requests. Middlewares see requests after the
+// authentication headers are set.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to the http.RoundTripper interface.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements the RoundTripper interface.
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// clientOptions holds the settings collected from the options.
+type clientOptions struct {
+	httpClient    *http.Client
+	baseTransport http.RoundTripper
+	timeout       time.Duration
+	proxy         func(*http.Request) (*url.URL, error)
+	tlsConfig     *tls.Config
+	userAgent     string
+	middlewares   []Middleware
+}
+
+// WithHTTPClient uses a copy of httpClient, keeping its timeout, cookie jar and redirect policy.
+// Its transport, if any, is used as the base transport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithBaseTransport sets the transport wrapped by the authentication layer, instead of http.DefaultTransport.
+func WithBaseTransport(transport http.RoundTripper) Option {
+	return func(o *clientOptions) {
+		o.baseTransport = transport
+	}
+}
+
+// WithTimeout sets the overall timeout of each HTTP request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithProxy sets the proxy function of the base transport, such as http.ProxyURL.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) Option {
+	return func(o *clientOptions) {
+		o.proxy = proxy
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of the base transport, for instance to trust a private CA.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(o *clientOptions) {
+		o.tlsConfig = tlsConfig
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithMiddleware appends middlewares to the transport chain. The first middleware is the outermost one.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(o *clientOptions) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// NewClientWithOptions initializes a new Sectigo API client like NewClient, with a customizable HTTP stack.
+// Authentication headers are always injected, whatever transport or middlewares are configured.
+func NewClientWithOptions(config Config, opts ...Option) (*Client, error) {
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	httpClient := &http.Client{}
+	if options.httpClient != nil {
+		*httpClient = *options.httpClient
+	}
+	if options.timeout > 0 {
+		httpClient.Timeout = options.timeout
+	}
+
+	transport := options.baseTransport
+	if transport == nil {
+		transport = httpClient.Transport
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	password := config.Password
+	var credentials *credentialCache
+	if config.CredentialProvider != nil {
+		credentials = newCredentialCache(config.CredentialProvider, config.CredentialCacheTTL)
+	}
+	if config.AuthMode == AuthModeClientCertificate {
+		certificate, err := loadClientCertificate(config)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if options.tlsConfig != nil {
+			tlsConfig = options.tlsConfig.Clone()
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+		options.tlsConfig = tlsConfig
+		password = ""
+		credentials = nil
+	} else if config.AuthMode != "" && config.AuthMode != AuthModePassword {
+		return nil, fmt.Errorf("unsupported auth mode %q", config.AuthMode)
+	}
+
+	if options.proxy != nil || options.tlsConfig != nil {
+		httpTransport, ok := transport.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("proxy and TLS options require the base transport to be an *http.Transport, got %T", transport)
+		}
+		httpTransport = httpTransport.Clone()
+		if options.proxy != nil {
+			httpTransport.Proxy = options.proxy
+		}
+		if options.tlsConfig != nil {
+			httpTransport.TLSClientConfig = options.tlsConfig
+		}
+		transport = httpTransport
+	}
+
+	for i := len(options.middlewares) - 1; i >= 0; i-- {
+		transport = options.middlewares[i](transport)
+	}
+
+	httpClient.Transport = &authTransport{
+		login:       config.Username,
+		customerUri: config.Customer,
+		authMode:    config.AuthMode,
+		password:    password,
+		credentials: credentials,
+		userAgent:   options.userAgent,
+		transport:   transport,
+		logger:      newRequestLogger(config),
+	}
+
+	return newClient(config, httpClient), nil
+}
@@ -0,0 +1,130 @@
+package sectigo
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientWithOptions_MiddlewareChain(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/organization/v1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test", r.Header.Get("login"))
+		assert.Equal(t, "test", r.Header.Get("password"))
+		assert.Equal(t, "sectigo-tests/1.0", r.Header.Get("User-Agent"))
+		assert.Equal(t, "outer,inner", r.Header.Get("X-Chain"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[]`)) //nolint:errcheck
+	})
+
+	chain := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				// Auth headers are already set when middlewares run.
+				assert.Equal(t, "test", req.Header.Get("login"))
+				if previous := req.Header.Get("X-Chain"); previous != "" {
+					name = previous + "," + name
+				}
+				req.Header.Set("X-Chain", name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	client, err := NewClientWithOptions(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+	},
+		WithBaseTransport(mockClient.Client.Transport),
+		WithUserAgent("sectigo-tests/1.0"),
+		WithMiddleware(chain("outer"), chain("inner")),
+	)
+	assert.NoError(t, err)
+
+	_, err = client.ListOrganization(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestNewClientWithOptions_TLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test", r.Header.Get("customerUri"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[]`)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	config := Config{URL: server.URL, Username: "test", Customer: "test", Password: "test"}
+
+	// The default transport does not trust the test server certificate.
+	_, err := NewClient(config).ListOrganization(context.Background())
+	assert.Error(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	client, err := NewClientWithOptions(config, WithTLSConfig(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}))
+	assert.NoError(t, err)
+
+	_, err = client.ListOrganization(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestNewClientWithOptions_Proxy(t *testing.T) {
+	var proxied bool
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = true
+		assert.Equal(t, "cert-manager.example.com", r.Host)
+		assert.Equal(t, "test", r.Header.Get("login"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[]`)) //nolint:errcheck
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	client, err := NewClientWithOptions(Config{
+		URL:      "http://cert-manager.example.com",
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+	}, WithProxy(http.ProxyURL(proxyURL)))
+	assert.NoError(t, err)
+
+	_, err = client.ListOrganization(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, proxied)
+}
+
+func TestNewClientWithOptions_ProxyRequiresHTTPTransport(t *testing.T) {
+	_, err := NewClientWithOptions(Config{URL: "https://cert-manager.com"},
+		WithBaseTransport(RoundTripperFunc(func(req *http.Request) (*http.Response, error) { return nil, nil })),
+		WithProxy(http.ProxyFromEnvironment),
+	)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "require the base transport to be an *http.Transport")
+}
+
+func TestNewClientWithOptions_HTTPClient(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+
+	client, err := NewClientWithOptions(Config{URL: "https://cert-manager.com"},
+		WithHTTPClient(httpClient),
+		WithTimeout(5*time.Second),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, client.Client.Timeout)
+	assert.IsType(t, &authTransport{}, client.Client.Transport)
+
+	// The caller's client is left untouched.
+	assert.Equal(t, time.Minute, httpClient.Timeout)
+	assert.Nil(t, httpClient.Transport)
+}
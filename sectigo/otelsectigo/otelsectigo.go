@@ -0,0 +1,157 @@
+// Package otelsectigo records OpenTelemetry traces and metrics for the API calls made by a sectigo.Client.
+// It is a separate module, so only its users depend on OpenTelemetry.
+//
+// Usage:
+//
+//	client := sectigo.NewClient(sectigo.Config{
+//		// ...
+//		Instrumentation: otelsectigo.New(),
+//	})
+package otelsectigo
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+// ScopeName is the instrumentation scope name of the tracer and meter.
+const ScopeName = "github.com/fgouteroux/sectigo-client/sectigo/otelsectigo"
+
+// Attribute keys set on spans and metrics.
+const (
+	AttributeOperation = attribute.Key("sectigo.operation")
+	AttributeErrorCode = attribute.Key("sectigo.error_code")
+	AttributePosition  = attribute.Key("sectigo.page.position")
+	AttributeAttempt   = attribute.Key("sectigo.attempt")
+	AttributeMethod    = attribute.Key("http.request.method")
+	AttributeStatus    = attribute.Key("http.response.status_code")
+	AttributeURL       = attribute.Key("url.full")
+)
+
+// Option configures the Instrumentation returned by New.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the TracerProvider used to create spans. Defaults to the global provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the MeterProvider used to record metrics. Defaults to the global provider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// Instrumentation implements sectigo.Instrumentation with OpenTelemetry.
+// Each attempt is recorded as a client span named after the operation, e.g. "sectigo.ListSSL", and the
+// following metrics are recorded:
+//   - sectigo.client.request.duration: histogram of the attempt durations in seconds
+//   - sectigo.client.request.errors: counter of failed attempts
+//   - sectigo.client.request.retries: counter of retried attempts
+type Instrumentation struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+	retries  metric.Int64Counter
+}
+
+var _ sectigo.Instrumentation = (*Instrumentation)(nil)
+
+// New creates an Instrumentation.
+func New(opts ...Option) *Instrumentation {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	meter := cfg.meterProvider.Meter(ScopeName)
+	i := &Instrumentation{tracer: cfg.tracerProvider.Tracer(ScopeName)}
+
+	var err error
+	i.duration, err = meter.Float64Histogram("sectigo.client.request.duration",
+		metric.WithDescription("Duration of the Sectigo API requests."),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	i.errors, err = meter.Int64Counter("sectigo.client.request.errors",
+		metric.WithDescription("Number of failed Sectigo API requests."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	i.retries, err = meter.Int64Counter("sectigo.client.request.retries",
+		metric.WithDescription("Number of retried Sectigo API requests."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return i
+}
+
+// StartRequest implements sectigo.Instrumentation.
+func (i *Instrumentation) StartRequest(ctx context.Context, info sectigo.RequestInfo) (context.Context, func(sectigo.RequestResult)) {
+	attrs := []attribute.KeyValue{
+		AttributeOperation.String(info.Operation),
+		AttributeMethod.String(info.Method),
+	}
+	spanAttrs := append([]attribute.KeyValue{
+		AttributeURL.String(info.URL),
+		AttributeAttempt.Int(info.Attempt),
+	}, attrs...)
+	if info.Position >= 0 {
+		spanAttrs = append(spanAttrs, AttributePosition.Int(info.Position))
+	}
+
+	ctx, span := i.tracer.Start(ctx, "sectigo."+info.Operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(spanAttrs...))
+
+	if info.Attempt > 1 && i.retries != nil {
+		i.retries.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+
+	return ctx, func(result sectigo.RequestResult) {
+		var resultAttrs []attribute.KeyValue
+		if result.StatusCode != 0 {
+			resultAttrs = append(resultAttrs, AttributeStatus.Int(result.StatusCode))
+		}
+		if result.ErrorCode != 0 {
+			resultAttrs = append(resultAttrs, AttributeErrorCode.Int(result.ErrorCode))
+		}
+		span.SetAttributes(resultAttrs...)
+		attrs = append(attrs, resultAttrs...)
+
+		if result.Err != nil {
+			span.RecordError(result.Err)
+			span.SetStatus(codes.Error, result.Err.Error())
+			if i.errors != nil {
+				i.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+			}
+		}
+
+		if i.duration != nil {
+			i.duration.Record(ctx, result.Duration.Seconds(), metric.WithAttributes(attrs...))
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,107 @@
+package otelsectigo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+func newTestInstrumentation() (*Instrumentation, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+
+	instrumentation := New(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+
+	return instrumentation, exporter, reader
+}
+
+func collectMetrics(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+
+	metrics := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	return metrics
+}
+
+func TestStartRequest_Success(t *testing.T) {
+	instrumentation, exporter, reader := newTestInstrumentation()
+
+	ctx, end := instrumentation.StartRequest(context.Background(), sectigo.RequestInfo{
+		Operation: "ListSSL",
+		Method:    "GET",
+		URL:       "https://cert-manager.com/api/ssl/v1?position=200&size=200",
+		Attempt:   1,
+		Position:  200,
+	})
+	assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+	end(sectigo.RequestResult{StatusCode: 200, Duration: 10 * time.Millisecond})
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		span := spans[0]
+		assert.Equal(t, "sectigo.ListSSL", span.Name)
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+		assert.Equal(t, codes.Unset, span.Status.Code)
+		assert.Contains(t, span.Attributes, AttributeOperation.String("ListSSL"))
+		assert.Contains(t, span.Attributes, AttributeMethod.String("GET"))
+		assert.Contains(t, span.Attributes, AttributePosition.Int(200))
+		assert.Contains(t, span.Attributes, AttributeStatus.Int(200))
+	}
+
+	metrics := collectMetrics(t, reader)
+	if duration, ok := metrics["sectigo.client.request.duration"].(metricdata.Histogram[float64]); assert.True(t, ok) {
+		assert.Equal(t, uint64(1), duration.DataPoints[0].Count)
+	}
+	assert.NotContains(t, metrics, "sectigo.client.request.errors")
+	assert.NotContains(t, metrics, "sectigo.client.request.retries")
+}
+
+func TestStartRequest_ErrorAndRetry(t *testing.T) {
+	instrumentation, exporter, reader := newTestInstrumentation()
+
+	_, end := instrumentation.StartRequest(context.Background(), sectigo.RequestInfo{
+		Operation: "GetSSLDetails",
+		Method:    "GET",
+		Attempt:   2,
+		Position:  -1,
+	})
+	end(sectigo.RequestResult{StatusCode: 400, ErrorCode: -1400, Err: errors.New("not found")})
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		span := spans[0]
+		assert.Equal(t, codes.Error, span.Status.Code)
+		assert.Contains(t, span.Attributes, AttributeErrorCode.Int(-1400))
+		assert.Contains(t, span.Attributes, AttributeAttempt.Int(2))
+		for _, attr := range span.Attributes {
+			assert.NotEqual(t, AttributePosition, attr.Key)
+		}
+	}
+
+	metrics := collectMetrics(t, reader)
+	if errs, ok := metrics["sectigo.client.request.errors"].(metricdata.Sum[int64]); assert.True(t, ok) {
+		assert.Equal(t, int64(1), errs.DataPoints[0].Value)
+	}
+	if retries, ok := metrics["sectigo.client.request.retries"].(metricdata.Sum[int64]); assert.True(t, ok) {
+		assert.Equal(t, int64(1), retries.DataPoints[0].Value)
+	}
+}
@@ -0,0 +1,180 @@
+package sectigo
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+)
+
+// defaultPageSize is the page size used by the ListAll* functions and by pagers without an explicit size.
+const defaultPageSize = 200
+
+// PageFunc fetches the page of items starting at position and returns them with the total count reported by the API.
+type PageFunc[T any] func(ctx context.Context, position, size int) ([]T, int, error)
+
+// Pager iterates over a paginated list endpoint using the position/size query parameters and the X-Total-Count header.
+type Pager[T any] struct {
+	fetch       PageFunc[T]
+	pageSize    int
+	concurrency int
+}
+
+// NewPager creates a Pager fetching pages of pageSize items with fetch. A pageSize below 1 uses the default of 200.
+func NewPager[T any](fetch PageFunc[T], pageSize int) *Pager[T] {
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+
+	return &Pager[T]{
+		fetch:    fetch,
+		pageSize: pageSize,
+	}
+}
+
+// WithConcurrency sets the maximum number of pages fetched in parallel by Collect. A value below 2 fetches pages serially.
+func (p *Pager[T]) WithConcurrency(concurrency int) *Pager[T] {
+	p.concurrency = concurrency
+	return p
+}
+
+// All returns an iterator over every item, fetching one page at a time so memory usage stays constant.
+// Iteration stops at the first error, which is yielded with the zero value of T.
+func (p *Pager[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		position := 0
+		for {
+			items, totalCount, err := p.fetch(ctx, position, p.pageSize)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(items) < p.pageSize || position+p.pageSize >= totalCount {
+				return
+			}
+
+			position += p.pageSize
+		}
+	}
+}
+
+// Collect fetches every item and returns them in a single slice.
+// When a concurrency above 1 is set, the pages announced by the X-Total-Count header of a page are fetched
+// in parallel and reassembled in order, giving the same result as the serial path.
+func (p *Pager[T]) Collect(ctx context.Context) ([]T, error) {
+	if p.concurrency > 1 {
+		return p.collectConcurrent(ctx)
+	}
+
+	var items []T
+	for item, err := range p.All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// pageResult holds the outcome of fetching a single page.
+type pageResult[T any] struct {
+	items      []T
+	totalCount int
+	err        error
+}
+
+// collectConcurrent fetches the first page serially, then every remaining page it announces with a bounded
+// worker pool. Pages are consumed in order with the same stop condition as All, so a total count changing
+// mid-scan truncates or extends the result exactly like the serial path.
+func (p *Pager[T]) collectConcurrent(ctx context.Context) ([]T, error) {
+	var items []T
+	position := 0
+	for {
+		page, totalCount, err := p.fetch(ctx, position, p.pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page...)
+		if len(page) < p.pageSize || position+p.pageSize >= totalCount {
+			return items, nil
+		}
+		position += p.pageSize
+
+		var positions []int
+		for next := position; next < totalCount; next += p.pageSize {
+			positions = append(positions, next)
+		}
+
+		for i, result := range p.fetchPages(ctx, positions) {
+			if result.err != nil {
+				return nil, result.err
+			}
+
+			items = append(items, result.items...)
+			if len(result.items) < p.pageSize || positions[i]+p.pageSize >= result.totalCount {
+				return items, nil
+			}
+		}
+		position = positions[len(positions)-1] + p.pageSize
+	}
+}
+
+// fetchPages fetches the pages at the given positions with at most p.concurrency requests in flight
+// and returns their results in the same order. The first failure cancels the pages not fetched yet,
+// which then report that failure.
+func (p *Pager[T]) fetchPages(ctx context.Context, positions []int) []pageResult[T] {
+	results := make([]pageResult[T], len(positions))
+	indexes := make(chan int)
+
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var failOnce sync.Once
+	var firstErr error
+
+	var wg sync.WaitGroup
+	for range min(p.concurrency, len(positions)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := fetchCtx.Err(); err != nil {
+					results[i].err = err
+					continue
+				}
+				results[i].items, results[i].totalCount, results[i].err = p.fetch(fetchCtx, positions[i], p.pageSize)
+				if results[i].err != nil {
+					failOnce.Do(func() {
+						firstErr = results[i].err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+	for i := range positions {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil && ctx.Err() == nil {
+		for i := range results {
+			if errors.Is(results[i].err, context.Canceled) {
+				results[i].err = firstErr
+			}
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,255 @@
+package sectigo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPager_All(t *testing.T) {
+	var positions []int
+	pager := NewPager(func(ctx context.Context, position, size int) ([]int, int, error) {
+		positions = append(positions, position)
+		var items []int
+		for i := position; i < min(position+size, 7); i++ {
+			items = append(items, i)
+		}
+		return items, 7, nil
+	}, 3)
+
+	var items []int
+	for item, err := range pager.All(context.Background()) {
+		assert.NoError(t, err)
+		items = append(items, item)
+	}
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6}, items)
+	assert.Equal(t, []int{0, 3, 6}, positions)
+}
+
+func TestPager_AllStopEarly(t *testing.T) {
+	fetches := 0
+	pager := NewPager(func(ctx context.Context, position, size int) ([]int, int, error) {
+		fetches++
+		return []int{position, position + 1}, 100, nil
+	}, 2)
+
+	var items []int
+	for item, err := range pager.All(context.Background()) {
+		assert.NoError(t, err)
+		items = append(items, item)
+		if len(items) == 3 {
+			break
+		}
+	}
+
+	assert.Equal(t, []int{0, 1, 2}, items)
+	assert.Equal(t, 2, fetches)
+}
+
+func TestPager_CollectError(t *testing.T) {
+	pager := NewPager(func(ctx context.Context, position, size int) ([]int, int, error) {
+		if position > 0 {
+			return nil, 0, fmt.Errorf("page failed")
+		}
+		return []int{1, 2}, 10, nil
+	}, 2)
+
+	items, err := pager.Collect(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, items)
+	assert.Equal(t, "page failed", err.Error())
+}
+
+func TestNewPager_DefaultPageSize(t *testing.T) {
+	pager := NewPager(func(ctx context.Context, position, size int) ([]int, int, error) {
+		assert.Equal(t, defaultPageSize, size)
+		return nil, 0, nil
+	}, 0)
+
+	items, err := pager.Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestSSLPager(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2", r.URL.Query().Get("size"))
+		position, _ := strconv.Atoi(r.URL.Query().Get("position"))
+
+		var certificates []SSLCertificate
+		for i := position; i < min(position+2, 5); i++ {
+			certificates = append(certificates, SSLCertificate{SSLId: i + 1})
+		}
+
+		w.Header().Set("X-Total-Count", "5")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(certificates)
+	})
+
+	client := NewClient(Config{
+		URL:      mockClient.Server.URL,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+	})
+	client.Client = mockClient.Client
+
+	var sslIds []int
+	for certificate, err := range client.SSLPager(ListSSLParams{Size: 2}).All(context.Background()) {
+		assert.NoError(t, err)
+		sslIds = append(sslIds, certificate.SSLId)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, sslIds)
+}
+
+// changingTotalFetch simulates a listing whose total count changes after the first page.
+func changingTotalFetch(initialTotal, laterTotal int) PageFunc[int] {
+	return func(ctx context.Context, position, size int) ([]int, int, error) {
+		total := laterTotal
+		if position == 0 {
+			total = initialTotal
+		}
+		var items []int
+		for i := position; i < min(position+size, total); i++ {
+			items = append(items, i)
+		}
+		return items, total, nil
+	}
+}
+
+func TestPager_CollectConcurrent(t *testing.T) {
+	tests := []struct {
+		name         string
+		initialTotal int
+		laterTotal   int
+	}{
+		{name: "stable total", initialTotal: 23, laterTotal: 23},
+		{name: "total grows mid-scan", initialTotal: 10, laterTotal: 31},
+		{name: "total shrinks mid-scan", initialTotal: 30, laterTotal: 14},
+		{name: "single page", initialTotal: 3, laterTotal: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			serial, err := NewPager(changingTotalFetch(tt.initialTotal, tt.laterTotal), 4).Collect(ctx)
+			assert.NoError(t, err)
+
+			concurrent, err := NewPager(changingTotalFetch(tt.initialTotal, tt.laterTotal), 4).WithConcurrency(3).Collect(ctx)
+			assert.NoError(t, err)
+
+			assert.Equal(t, serial, concurrent)
+		})
+	}
+}
+
+func TestPager_CollectConcurrentLimit(t *testing.T) {
+	var inFlight, maxInFlight int32
+	pager := NewPager(func(ctx context.Context, position, size int) ([]int, int, error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return []int{position}, 20, nil
+	}, 1).WithConcurrency(4)
+
+	items, err := pager.Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 20, len(items))
+	for i, item := range items {
+		assert.Equal(t, i, item)
+	}
+	assert.LessOrEqual(t, maxInFlight, int32(4))
+	assert.Greater(t, maxInFlight, int32(1))
+}
+
+func TestPager_CollectConcurrentError(t *testing.T) {
+	var mu sync.Mutex
+	var positions []int
+	pager := NewPager(func(ctx context.Context, position, size int) ([]int, int, error) {
+		mu.Lock()
+		positions = append(positions, position)
+		mu.Unlock()
+		if position == 4 {
+			return nil, 0, fmt.Errorf("page %d failed", position)
+		}
+		return []int{position, position + 1}, 10, nil
+	}, 2).WithConcurrency(2)
+
+	items, err := pager.Collect(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, items)
+	assert.Equal(t, "page 4 failed", err.Error())
+	assert.Subset(t, positions, []int{0, 2, 4})
+}
+
+func TestPager_CollectConcurrentErrorCancelsRemainingPages(t *testing.T) {
+	var fetches int32
+	pager := NewPager(func(ctx context.Context, position, size int) ([]int, int, error) {
+		atomic.AddInt32(&fetches, 1)
+		if position == 1 {
+			return nil, 0, fmt.Errorf("page %d failed", position)
+		}
+		time.Sleep(time.Millisecond)
+		return []int{position}, 50, nil
+	}, 1).WithConcurrency(2)
+
+	_, err := pager.Collect(context.Background())
+	assert.EqualError(t, err, "page 1 failed")
+	assert.Less(t, atomic.LoadInt32(&fetches), int32(10))
+}
+
+func TestListAllSSL_Concurrent(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	mockClient.Mux.HandleFunc("/api/ssl/v1", func(w http.ResponseWriter, r *http.Request) {
+		position, _ := strconv.Atoi(r.URL.Query().Get("position"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+
+		var certificates []SSLCertificate
+		for i := position; i < min(position+size, 450); i++ {
+			certificates = append(certificates, SSLCertificate{SSLId: i + 1})
+		}
+
+		w.Header().Set("X-Total-Count", "450")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(certificates)
+	})
+
+	client := NewClient(Config{
+		URL:             mockClient.Server.URL,
+		Username:        "test",
+		Customer:        "test",
+		Password:        "test",
+		Debug:           false,
+		PageConcurrency: 4,
+	})
+	client.Client = mockClient.Client
+
+	sslCertificates, err := client.ListAllSSL(context.Background(), ListSSLParams{})
+	assert.NoError(t, err)
+	assert.Equal(t, 450, len(sslCertificates))
+	for i, certificate := range sslCertificates {
+		assert.Equal(t, i+1, certificate.SSLId)
+	}
+}
@@ -0,0 +1,140 @@
+package sectigo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how the client retries requests failing with transient errors.
+// Only idempotent methods are retried unless RetryNonIdempotent is set or the request context
+// was marked with WithReplayableRequest.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one. A value below 2 disables retries.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubled on every following attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the computed backoff and the wait requested through Retry-After.
+	MaxBackoff time.Duration
+	// Jitter is the fraction, between 0 and 1, of the backoff that is randomized.
+	Jitter float64
+	// RetryNonIdempotent enables retries for POST requests and other non-idempotent methods.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a retry policy suitable for most callers.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Jitter:         0.5,
+	}
+}
+
+// replayableRequestKey is the context key marking a request as safe to replay.
+type replayableRequestKey struct{}
+
+// WithReplayableRequest marks the requests made with ctx as safe to replay, so they are retried
+// even when their method is not idempotent.
+func WithReplayableRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replayableRequestKey{}, true)
+}
+
+// retryableStatusCodes lists the status codes considered transient.
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// allows reports whether the policy permits retrying req.
+func (p RetryPolicy) allows(req *http.Request) bool {
+	if p.MaxAttempts < 2 {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	replayable, _ := req.Context().Value(replayableRequestKey{}).(bool)
+	return p.RetryNonIdempotent || replayable
+}
+
+// shouldRetry reports whether a failed attempt is transient and worth retrying.
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return retryableStatusCodes[apiErr.StatusCode]
+	}
+
+	// Any other error without a response is a transport failure such as a connection reset.
+	return err != nil && resp == nil
+}
+
+// backoff returns the wait before the given retry, honoring the Retry-After header of resp when present.
+func (p RetryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 {
+				wait = min(wait, p.MaxBackoff)
+			}
+			return wait
+		}
+	}
+
+	wait := p.InitialBackoff
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 {
+		wait = min(wait, p.MaxBackoff)
+	}
+
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 && wait > 0 {
+		wait -= time.Duration(rand.Float64() * jitter * float64(wait))
+	}
+
+	return wait
+}
+
+// parseRetryAfter parses a Retry-After header expressed either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// rewindRequest returns a copy of req with a fresh body so it can be sent again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	retryReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("error rewinding request body: %w", err)
+		}
+		retryReq.Body = body
+	}
+	return retryReq, nil
+}
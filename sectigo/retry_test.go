@@ -0,0 +1,189 @@
+package sectigo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newRetryTestClient(url string, policy RetryPolicy) *Client {
+	return NewClient(Config{
+		URL:      url,
+		Username: "test",
+		Customer: "test",
+		Password: "test",
+		Debug:    false,
+		Retry:    policy,
+	})
+}
+
+func TestSendRequest_RetryTransientStatus(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	attempts := 0
+	mockClient.Mux.HandleFunc("/api/ssl/v1/1638", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(SSLDetails{SSLId: 1638})
+	})
+
+	client := newRetryTestClient(mockClient.Server.URL, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	sslDetails, err := client.GetSSLDetails(context.Background(), 1638)
+	assert.NoError(t, err)
+	assert.Equal(t, 1638, sslDetails.SSLId)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestSendRequest_RetryExhausted(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	attempts := 0
+	mockClient.Mux.HandleFunc("/api/ssl/v1/1638", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client := newRetryTestClient(mockClient.Server.URL, RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+
+	_, err := client.GetSSLDetails(context.Background(), 1638)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrServerError))
+	assert.Equal(t, 2, attempts)
+}
+
+func TestSendRequest_NoRetryForPermanentError(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	attempts := 0
+	mockClient.Mux.HandleFunc("/api/ssl/v1/1638", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	client := newRetryTestClient(mockClient.Server.URL, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	_, err := client.GetSSLDetails(context.Background(), 1638)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Equal(t, 1, attempts)
+}
+
+func TestSendRequest_NoRetryForPOSTByDefault(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	attempts := 0
+	mockClient.Mux.HandleFunc("/api/ssl/v1/enroll", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client := newRetryTestClient(mockClient.Server.URL, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	_, err := client.EnrollSSL(context.Background(), EnrollSSLRequest{OrgId: 1, CertType: 17, Term: 365, CSR: "MIIB"})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestSendRequest_RetryReplayablePOST(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	var bodies []string
+	mockClient.Mux.HandleFunc("/api/ssl/v1/enroll", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(EnrollSSLResponse{SSLId: 1740})
+	})
+
+	client := newRetryTestClient(mockClient.Server.URL, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	ctx := WithReplayableRequest(context.Background())
+	enrollResponse, err := client.EnrollSSL(ctx, EnrollSSLRequest{OrgId: 1, CertType: 17, Term: 365, CSR: "MIIB"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1740, enrollResponse.SSLId)
+	assert.Equal(t, 2, len(bodies))
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func TestSendRequest_RetryConnectionError(t *testing.T) {
+	mockClient := NewMockClient()
+	url := mockClient.Server.URL
+	mockClient.Close()
+
+	client := newRetryTestClient(url, RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+
+	_, err := client.GetSSLDetails(context.Background(), 1638)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error making request")
+}
+
+func TestSendRequest_RetryContextCancelled(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	attempts := 0
+	mockClient.Mux.HandleFunc("/api/ssl/v1/1638", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client := newRetryTestClient(mockClient.Server.URL, RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.GetSSLDetails(ctx, 1638)
+	assert.True(t, errors.Is(err, ErrServerError))
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1, nil))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(2, nil))
+	assert.Equal(t, 400*time.Millisecond, policy.backoff(3, nil))
+	assert.Equal(t, time.Second, policy.backoff(10, nil))
+
+	policy.Jitter = 0.5
+	for range 20 {
+		wait := policy.backoff(2, nil)
+		assert.GreaterOrEqual(t, wait, 100*time.Millisecond)
+		assert.LessOrEqual(t, wait, 200*time.Millisecond)
+	}
+}
+
+func TestRetryPolicy_BackoffRetryAfter(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: 10 * time.Second}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "3")
+	assert.Equal(t, 3*time.Second, policy.backoff(1, resp))
+
+	resp.Header.Set("Retry-After", "120")
+	assert.Equal(t, 10*time.Second, policy.backoff(1, resp))
+
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.Equal(t, time.Duration(0), policy.backoff(1, resp))
+
+	resp.Header.Set("Retry-After", "soon")
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1, resp))
+}
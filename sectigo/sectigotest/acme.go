@@ -0,0 +1,177 @@
+package sectigotest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+// ACME account statuses used by the server.
+const (
+	AcmeAccountValid   = "valid"
+	AcmeAccountPending = "pending"
+)
+
+// acmeAccount is an ACME account held by the server.
+type acmeAccount struct {
+	account sectigo.AcmeAccount
+	domains []string
+}
+
+// AddOrganization adds an organization and returns its ID.
+func (s *Server) AddOrganization(organization sectigo.Organization) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	organization.ID = s.newId()
+	s.organizations = append(s.organizations, organization)
+	return organization.ID
+}
+
+// AddAcmeAccount adds an ACME account and returns its ID. The status defaults to valid.
+func (s *Server) AddAcmeAccount(account sectigo.AcmeAccount) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account.ID = s.newId()
+	if account.Status == "" {
+		account.Status = AcmeAccountValid
+	}
+	s.acmeAccounts = append(s.acmeAccounts, &acmeAccount{account: account})
+	return account.ID
+}
+
+// AcmeAccountDomains returns the domains of the ACME account with id.
+func (s *Server) AcmeAccountDomains(id int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a := s.findAcmeAccount(id)
+	if a == nil {
+		return nil
+	}
+	return slices.Clone(a.domains)
+}
+
+// findAcmeAccount returns the ACME account with id, or nil. s.mu must be held.
+func (s *Server) findAcmeAccount(id int) *acmeAccount {
+	for _, a := range s.acmeAccounts {
+		if a.account.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+// registerACMEHandlers registers the handlers of the organization and ACME APIs.
+func (s *Server) registerACMEHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/organization/v1", s.handleListOrganization)
+	mux.HandleFunc("GET /api/acme/v2/account", s.handleListAcmeAccount)
+	mux.HandleFunc("GET /api/acme/v2/account/{id}/domain", s.handleListAcmeAccountDomain)
+	mux.HandleFunc("POST /api/acme/v2/account/{id}/domain", s.handleAddAcmeAccountDomain)
+}
+
+func (s *Server) handleListOrganization(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	organizations := slices.Clone(s.organizations)
+	if organizations == nil {
+		organizations = []sectigo.Organization{}
+	}
+	writeJSON(w, http.StatusOK, organizations)
+}
+
+func (s *Server) handleListAcmeAccount(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := []sectigo.AcmeAccount{}
+	for _, a := range s.acmeAccounts {
+		if (queryInt(r, "organizationId") != 0 && queryInt(r, "organizationId") != a.account.OrganizationID) ||
+			(query.Has("name") && query.Get("name") != a.account.Name) ||
+			(query.Has("acmeServer") && query.Get("acmeServer") != a.account.AcmeServer) ||
+			(query.Has("certValidationType") && query.Get("certValidationType") != a.account.CertValidationType) ||
+			(query.Has("status") && query.Get("status") != a.account.Status) {
+			continue
+		}
+		items = append(items, a.account)
+	}
+
+	writePage(w, r, items)
+}
+
+func (s *Server) handleListAcmeAccountDomain(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	a, unlock, ok := s.lockAcmeAccount(w, r)
+	if !ok {
+		return
+	}
+	defer unlock()
+
+	var expiresBefore string
+	if days := queryInt(r, "expiresWithinNextDays"); days > 0 {
+		expiresBefore = s.now().AddDate(0, 0, days).Format(dateLayout)
+	}
+
+	items := []sectigo.AcmeAccountDomain{}
+	for _, name := range a.domains {
+		validUntil := s.domainValidation(name).ExpirationDate
+		if (query.Has("name") && query.Get("name") != name) ||
+			(expiresBefore != "" && (validUntil == "" || validUntil > expiresBefore)) {
+			continue
+		}
+		items = append(items, sectigo.AcmeAccountDomain{Name: name, ValidUntil: validUntil})
+	}
+
+	writePage(w, r, items)
+}
+
+func (s *Server) handleAddAcmeAccountDomain(w http.ResponseWriter, r *http.Request) {
+	var request sectigo.AcmeAccountDomainRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	a, unlock, ok := s.lockAcmeAccount(w, r)
+	if !ok {
+		return
+	}
+	defer unlock()
+
+	for _, domain := range request.Domains {
+		if domain.Name == "" {
+			writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "Domain name is required")
+			return
+		}
+	}
+	for _, domain := range request.Domains {
+		if !slices.Contains(a.domains, domain.Name) {
+			a.domains = append(a.domains, domain.Name)
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// lockAcmeAccount locks the server and returns the ACME account identified by the id path value, writing an
+// error response and unlocking the server if it does not exist.
+func (s *Server) lockAcmeAccount(w http.ResponseWriter, r *http.Request) (*acmeAccount, func(), bool) {
+	id, ok := pathId(w, r, "id")
+	if !ok {
+		return nil, nil, false
+	}
+
+	s.mu.Lock()
+	a := s.findAcmeAccount(id)
+	if a == nil {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, ErrorCodeNotFound, fmt.Sprintf("ACME account %d not found", id))
+		return nil, nil, false
+	}
+	return a, s.mu.Unlock, true
+}
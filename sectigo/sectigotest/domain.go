@@ -0,0 +1,530 @@
+package sectigotest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+// Domain and delegation states used by the server.
+const (
+	DomainStateActive      = "ACTIVE"
+	DomainStateSuspended   = "SUSPENDED"
+	DelegationRequested    = "REQUESTED"
+	DelegationActive       = "ACTIVE"
+	DelegationNotDelegated = "NOT_DELEGATED"
+)
+
+// Domain control validation statuses used by the server.
+const (
+	DCVStatusNotValidated  = "NOT_VALIDATED"
+	DCVStatusValidated     = "VALIDATED"
+	DCVStatusExpired       = "EXPIRED"
+	DCVOrderNotInitiated   = "NOT_INITIATED"
+	DCVOrderAwaitingSubmit = "AWAITING_SUBMIT"
+	DCVOrderSubmitted      = "SUBMITTED"
+	DCVMethodCNAME         = "CNAME"
+	DCVMethodHTTP          = "HTTP"
+	DCVMethodHTTPS         = "HTTPS"
+	DCVMethodTXT           = "TXT"
+	DCVMethodEmail         = "EMAIL"
+)
+
+const (
+	// dcvValidityDays is the number of days a domain control validation remains valid.
+	dcvValidityDays = 395
+	// dcvRandomValueByteLength is the number of random bytes of the DNS records of a validation.
+	dcvRandomValueByteLength = 16
+)
+
+// delegation is the type of the delegations of sectigo.DomainDetails.
+type delegation = struct {
+	OrgId     int      `json:"orgId"`
+	CertTypes []string `json:"certTypes"`
+	Status    string   `json:"status"`
+}
+
+// domain is a domain held by the server.
+type domain struct {
+	id          int
+	name        string
+	description string
+	active      bool
+	delegations []delegation
+}
+
+// validation is the domain control validation of a domain name.
+type validation struct {
+	domain         string
+	method         string
+	status         string
+	orderStatus    string
+	expirationDate string
+	// host is the CNAME or TXT record name, or the URL of the validation file.
+	host string
+	// point is the CNAME target, the TXT record value, or the first line of the validation file.
+	point string
+	// emails are the approver emails of an email validation.
+	emails []string
+}
+
+// AddDomain adds a domain and returns its ID. Its delegations are active.
+func (s *Server) AddDomain(request sectigo.DomainRequest) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.newDomain(request).id
+}
+
+// Domain returns the details of the domain with id.
+func (s *Server) Domain(id int) (sectigo.DomainDetails, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.findDomain(id)
+	if d == nil {
+		return sectigo.DomainDetails{}, false
+	}
+	return s.domainDetails(d), true
+}
+
+// ValidateDomain completes the domain control validation of name, as if the DNS record had been checked.
+func (s *Server) ValidateDomain(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := s.validation(name)
+	if v.method == "" {
+		v.method = DCVMethodCNAME
+	}
+	s.validate(v)
+}
+
+// DomainValidation returns the domain control validation of name.
+func (s *Server) DomainValidation(name string) sectigo.DomainValidation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.domainValidation(name)
+}
+
+// newDomain adds a domain. s.mu must be held.
+func (s *Server) newDomain(request sectigo.DomainRequest) *domain {
+	d := &domain{id: s.newId(), name: request.Name, description: request.Description, active: request.Active}
+	for _, r := range request.Delegations {
+		d.delegations = append(d.delegations, delegation{OrgId: r.OrgId, CertTypes: r.CertTypes, Status: DelegationActive})
+	}
+	s.domains = append(s.domains, d)
+	return d
+}
+
+// findDomain returns the domain with id, or nil. s.mu must be held.
+func (s *Server) findDomain(id int) *domain {
+	for _, d := range s.domains {
+		if d.id == id {
+			return d
+		}
+	}
+	return nil
+}
+
+// domainDetails returns the details of d. s.mu must be held.
+func (s *Server) domainDetails(d *domain) sectigo.DomainDetails {
+	v := s.domainValidation(d.name)
+	details := sectigo.DomainDetails{
+		ID:               d.id,
+		Name:             d.name,
+		Description:      d.description,
+		DelegationStatus: delegationStatus(d.delegations),
+		State:            DomainStateActive,
+		ValidationStatus: v.DcvStatus,
+		ValidationMethod: v.DcvMethod,
+		DcvExpiration:    v.ExpirationDate,
+		Delegations:      slices.Clone(d.delegations),
+	}
+	if !d.active {
+		details.State = DomainStateSuspended
+	}
+	if details.Delegations == nil {
+		details.Delegations = []delegation{}
+	}
+	return details
+}
+
+// delegationStatus summarizes the status of delegations.
+func delegationStatus(delegations []delegation) string {
+	if len(delegations) == 0 {
+		return DelegationNotDelegated
+	}
+	for _, d := range delegations {
+		if d.Status == DelegationRequested {
+			return DelegationRequested
+		}
+	}
+	return DelegationActive
+}
+
+// validation returns the validation of name, creating it if needed. s.mu must be held.
+func (s *Server) validation(name string) *validation {
+	for _, v := range s.validations {
+		if v.domain == name {
+			return v
+		}
+	}
+	v := &validation{domain: name, status: DCVStatusNotValidated, orderStatus: DCVOrderNotInitiated}
+	s.validations = append(s.validations, v)
+	return v
+}
+
+// validate marks v validated. s.mu must be held.
+func (s *Server) validate(v *validation) {
+	v.status = DCVStatusValidated
+	v.orderStatus = DCVOrderSubmitted
+	v.expirationDate = s.now().AddDate(0, 0, dcvValidityDays).Format(dateLayout)
+}
+
+// domainValidation returns the validation of name as returned by the API. s.mu must be held.
+func (s *Server) domainValidation(name string) sectigo.DomainValidation {
+	result := sectigo.DomainValidation{Domain: name, DcvStatus: DCVStatusNotValidated, DcvOrderStatus: DCVOrderNotInitiated}
+	for _, v := range s.validations {
+		if v.domain != name {
+			continue
+		}
+		result.DcvStatus = v.status
+		result.DcvOrderStatus = v.orderStatus
+		result.DcvMethod = v.method
+		result.ExpirationDate = v.expirationDate
+		if v.status == DCVStatusValidated && v.expirationDate < s.today() {
+			result.DcvStatus = DCVStatusExpired
+		}
+	}
+	return result
+}
+
+// registerDomainHandlers registers the handlers of the domain and domain control validation APIs.
+func (s *Server) registerDomainHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/domain/v1", s.handleCreateDomain)
+	mux.HandleFunc("GET /api/domain/v1", s.handleListDomain)
+	mux.HandleFunc("GET /api/domain/v1/{id}", s.handleGetDomain)
+	mux.HandleFunc("PUT /api/domain/v1/{id}", s.handleUpdateDomain)
+	mux.HandleFunc("DELETE /api/domain/v1/{id}", s.handleDeleteDomain)
+	mux.HandleFunc("PUT /api/domain/v1/{id}/suspend", s.handleSetDomainActive(false))
+	mux.HandleFunc("PUT /api/domain/v1/{id}/activate", s.handleSetDomainActive(true))
+	mux.HandleFunc("POST /api/domain/v1/delegation", s.handleDelegateDomain)
+	mux.HandleFunc("POST /api/domain/v1/{id}/delegation/approve", s.handleApproveDelegation)
+
+	for _, method := range []string{DCVMethodCNAME, DCVMethodHTTP, DCVMethodHTTPS, DCVMethodTXT, DCVMethodEmail} {
+		path := strings.ToLower(method)
+		mux.HandleFunc("POST /api/dcv/v1/validation/start/domain/"+path, s.handleStartValidation(method))
+		mux.HandleFunc("POST /api/dcv/v1/validation/submit/domain/"+path, s.handleSubmitValidation(method))
+	}
+	mux.HandleFunc("POST /api/dcv/v2/validation/status", s.handleValidationStatus)
+	mux.HandleFunc("GET /api/dcv/v1/validation", s.handleListValidation)
+}
+
+func (s *Server) handleCreateDomain(w http.ResponseWriter, r *http.Request) {
+	var request sectigo.DomainRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+	if request.Name == "" {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "Domain name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, d := range s.domains {
+		if d.name == request.Name {
+			writeError(w, http.StatusConflict, ErrorCodeConflict, fmt.Sprintf("Domain %s already exists", request.Name))
+			return
+		}
+	}
+
+	d := s.newDomain(request)
+	w.Header().Set("Location", fmt.Sprintf("%s/api/domain/v1/%d", s.URL, d.id))
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) handleListDomain(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := []sectigo.Domain{}
+	for _, d := range s.domains {
+		details := s.domainDetails(d)
+		if (query.Has("name") && query.Get("name") != d.name) ||
+			(query.Has("state") && query.Get("state") != details.State) ||
+			(query.Has("status") && query.Get("status") != details.DelegationStatus) ||
+			(query.Has("orgId") && !slices.ContainsFunc(d.delegations, func(del delegation) bool { return del.OrgId == queryInt(r, "orgId") })) {
+			continue
+		}
+		items = append(items, sectigo.Domain{ID: d.id, Name: d.name})
+	}
+
+	writePage(w, r, items)
+}
+
+func (s *Server) handleGetDomain(w http.ResponseWriter, r *http.Request) {
+	d, unlock, ok := s.lockDomain(w, r)
+	if !ok {
+		return
+	}
+	defer unlock()
+
+	writeJSON(w, http.StatusOK, s.domainDetails(d))
+}
+
+func (s *Server) handleUpdateDomain(w http.ResponseWriter, r *http.Request) {
+	var request sectigo.UpdateDomainRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	d, unlock, ok := s.lockDomain(w, r)
+	if !ok {
+		return
+	}
+	defer unlock()
+
+	if request.Description != nil {
+		d.description = *request.Description
+	}
+	if request.Active != nil {
+		d.active = *request.Active
+	}
+	if request.Delegations != nil {
+		d.delegations = nil
+		for _, dr := range *request.Delegations {
+			d.delegations = append(d.delegations, delegation{OrgId: dr.OrgId, CertTypes: dr.CertTypes, Status: DelegationActive})
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleSetDomainActive returns a handler activating or suspending a domain.
+func (s *Server) handleSetDomainActive(active bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d, unlock, ok := s.lockDomain(w, r)
+		if !ok {
+			return
+		}
+		defer unlock()
+
+		d.active = active
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (s *Server) handleDeleteDomain(w http.ResponseWriter, r *http.Request) {
+	d, unlock, ok := s.lockDomain(w, r)
+	if !ok {
+		return
+	}
+	defer unlock()
+
+	s.domains = slices.DeleteFunc(s.domains, func(candidate *domain) bool { return candidate == d })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleDelegateDomain(w http.ResponseWriter, r *http.Request) {
+	var request sectigo.DelegateDomainRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var domains []*domain
+	for _, id := range request.DomainIds {
+		d := s.findDomain(id)
+		if d == nil {
+			writeError(w, http.StatusNotFound, ErrorCodeNotFound, fmt.Sprintf("Domain %d not found", id))
+			return
+		}
+		domains = append(domains, d)
+	}
+
+	for _, d := range domains {
+		d.delegations = slices.DeleteFunc(d.delegations, func(del delegation) bool { return del.OrgId == request.OrgId })
+		d.delegations = append(d.delegations, delegation{OrgId: request.OrgId, CertTypes: request.CertTypes, Status: DelegationRequested})
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleApproveDelegation(w http.ResponseWriter, r *http.Request) {
+	var request sectigo.ApproveDelegationRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	d, unlock, ok := s.lockDomain(w, r)
+	if !ok {
+		return
+	}
+	defer unlock()
+
+	i := slices.IndexFunc(d.delegations, func(del delegation) bool { return del.OrgId == request.OrgId })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, ErrorCodeNotFound, fmt.Sprintf("Domain is not delegated to organization %d", request.OrgId))
+		return
+	}
+	d.delegations[i].Status = DelegationActive
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleStartValidation returns a handler starting the domain control validation of a domain with method.
+func (s *Server) handleStartValidation(method string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Domain string `json:"domain"`
+		}
+		if !decodeJSON(w, r, &request) {
+			return
+		}
+		if request.Domain == "" {
+			writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "Domain is required")
+			return
+		}
+
+		random := make([]byte, dcvRandomValueByteLength)
+		_, _ = rand.Read(random)
+		value := hex.EncodeToString(random)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		v := s.validation(strings.TrimPrefix(request.Domain, "*."))
+		v.method = method
+		v.orderStatus = DCVOrderAwaitingSubmit
+
+		switch method {
+		case DCVMethodCNAME:
+			v.host = fmt.Sprintf("_%s.%s.", value[:16], v.domain)
+			v.point = fmt.Sprintf("%s.%s.sectigo.com.", value[:16], value[16:])
+			writeJSON(w, http.StatusOK, sectigo.StartDomainCNameValidationResponse{Host: v.host, Point: v.point})
+		case DCVMethodHTTP, DCVMethodHTTPS:
+			v.host = fmt.Sprintf("%s://%s/.well-known/pki-validation/%s.txt", strings.ToLower(method), v.domain, strings.ToUpper(value[:16]))
+			v.point = value
+			writeJSON(w, http.StatusOK, sectigo.StartDomainHTTPValidationResponse{URL: v.host, FirstLine: v.point, SecondLine: "sectigo.com"})
+		case DCVMethodTXT:
+			v.host = fmt.Sprintf("_dnsauth.%s.", v.domain)
+			v.point = value
+			writeJSON(w, http.StatusOK, sectigo.StartDomainTXTValidationResponse{Host: v.host, Value: v.point})
+		case DCVMethodEmail:
+			v.emails = nil
+			for _, mailbox := range []string{"admin", "administrator", "hostmaster", "postmaster", "webmaster"} {
+				v.emails = append(v.emails, mailbox+"@"+v.domain)
+			}
+			writeJSON(w, http.StatusOK, sectigo.StartDomainEmailValidationResponse{Emails: v.emails})
+		}
+	}
+}
+
+// handleSubmitValidation returns a handler submitting the domain control validation of a domain with method.
+func (s *Server) handleSubmitValidation(method string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Domain string `json:"domain"`
+			Email  string `json:"email"`
+		}
+		if !decodeJSON(w, r, &request) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		v := s.validation(strings.TrimPrefix(request.Domain, "*."))
+		if v.method != method || v.orderStatus != DCVOrderAwaitingSubmit {
+			writeError(w, http.StatusBadRequest, ErrorCodeInvalidStatus, "Validation was not started with this method")
+			return
+		}
+		if method == DCVMethodEmail && !slices.Contains(v.emails, request.Email) {
+			writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, fmt.Sprintf("%s is not an approver email of %s", request.Email, v.domain))
+			return
+		}
+		v.orderStatus = DCVOrderSubmitted
+		if s.autoValidate {
+			s.validate(v)
+		}
+
+		writeJSON(w, http.StatusOK, sectigo.SubmitDomainCNameValidationResponse{
+			OrderStatus: v.orderStatus,
+			Message:     "DCV request submitted",
+			Status:      v.status,
+		})
+	}
+}
+
+func (s *Server) handleValidationStatus(w http.ResponseWriter, r *http.Request) {
+	var request sectigo.GetDomainValidationStatusRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := s.domainValidation(strings.TrimPrefix(request.Domain, "*."))
+	writeJSON(w, http.StatusOK, sectigo.GetDomainValidationStatusResponse{
+		Status:         v.DcvStatus,
+		OrderStatus:    v.DcvOrderStatus,
+		ExpirationDate: v.ExpirationDate,
+	})
+}
+
+func (s *Server) handleListValidation(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var names []string
+	for _, d := range s.domains {
+		names = append(names, d.name)
+	}
+	for _, v := range s.validations {
+		if !slices.Contains(names, v.domain) {
+			names = append(names, v.domain)
+		}
+	}
+
+	items := []sectigo.DomainValidation{}
+	for _, name := range names {
+		v := s.domainValidation(name)
+		if (query.Has("domain") && query.Get("domain") != v.Domain) ||
+			(query.Has("dcvStatus") && query.Get("dcvStatus") != v.DcvStatus) ||
+			(query.Has("orderStatus") && query.Get("orderStatus") != v.DcvOrderStatus) {
+			continue
+		}
+		items = append(items, v)
+	}
+
+	writePage(w, r, items)
+}
+
+// lockDomain locks the server and returns the domain identified by the id path value, writing an error
+// response and unlocking the server if it does not exist.
+func (s *Server) lockDomain(w http.ResponseWriter, r *http.Request) (*domain, func(), bool) {
+	id, ok := pathId(w, r, "id")
+	if !ok {
+		return nil, nil, false
+	}
+
+	s.mu.Lock()
+	d := s.findDomain(id)
+	if d == nil {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, ErrorCodeNotFound, "Domain not found")
+		return nil, nil, false
+	}
+	return d, s.mu.Unlock, true
+}
@@ -0,0 +1,363 @@
+// Package sectigotest provides a stateful fake Sectigo Certificate Manager server for testing code built on
+// the sectigo package without a live tenant.
+//
+// The server keeps certificates, domains, domain control validations, ACME accounts and organizations in
+// memory, honors pagination and the X-Total-Count header like the real API, and can inject faults:
+//
+//	server := sectigotest.NewServer()
+//	defer server.Close()
+//
+//	orgId := server.AddOrganization(sectigo.Organization{Name: "Example"})
+//	client := server.Client()
+//	resp, err := client.EnrollSSL(ctx, sectigo.EnrollSSLRequest{OrgId: orgId, CertType: 1, Term: 365, CSR: csr})
+//	server.IssueCertificate(resp.SSLId)
+package sectigotest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+// Default credentials accepted by the server.
+const (
+	DefaultUsername = "test"
+	DefaultCustomer = "test"
+	DefaultPassword = "test"
+)
+
+// dateLayout is the layout of the dates returned by the server.
+const dateLayout = "2006-01-02"
+
+// Error codes returned by the server in the code field of error responses.
+const (
+	ErrorCodeUnauthorized  = -16
+	ErrorCodeInvalidInput  = -3
+	ErrorCodeNotFound      = -9
+	ErrorCodeInvalidStatus = -10
+	ErrorCodeNotIssued     = -183
+	ErrorCodeConflict      = -11
+	ErrorCodeFault         = -1
+)
+
+// Fault is an error or a latency injected in the responses of the server.
+type Fault struct {
+	// Method restricts the fault to requests with this HTTP method. Empty matches all methods.
+	Method string
+	// Path restricts the fault to requests whose path starts with this prefix. Empty matches all paths.
+	Path string
+	// StatusCode is the status code of the error response, 0 to only add Latency.
+	StatusCode int
+	// Latency delays the response.
+	Latency time.Duration
+	// RetryAfter, when set, is sent in the Retry-After header of the error response.
+	RetryAfter time.Duration
+	// Count is the number of requests affected by the fault, 0 for all of them.
+	Count int
+}
+
+// matches reports whether the fault applies to r.
+func (f *Fault) matches(r *http.Request) bool {
+	return (f.Method == "" || f.Method == r.Method) && strings.HasPrefix(r.URL.Path, f.Path)
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithCredentials sets the credentials accepted by the server.
+func WithCredentials(username, customer, password string) Option {
+	return func(s *Server) {
+		s.username, s.customer, s.password = username, customer, password
+	}
+}
+
+// WithAutoIssue makes the server issue enrolled, renewed and replaced certificates immediately.
+func WithAutoIssue() Option {
+	return func(s *Server) {
+		s.autoIssue = true
+	}
+}
+
+// WithAutoValidate makes the server validate domains as soon as their validation is submitted.
+func WithAutoValidate() Option {
+	return func(s *Server) {
+		s.autoValidate = true
+	}
+}
+
+// WithNow sets the function returning the current time of the server, used for dates.
+func WithNow(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithCertTypes sets the certificate types returned by the server. Defaults to a single "OV SSL" type with ID 1.
+func WithCertTypes(certTypes ...sectigo.CertType) Option {
+	return func(s *Server) {
+		s.certTypes = certTypes
+	}
+}
+
+// WithCustomFields sets the custom fields returned by the server.
+func WithCustomFields(customFields ...sectigo.CustomFieldDefinition) Option {
+	return func(s *Server) {
+		s.customFields = customFields
+	}
+}
+
+// Server is a fake Sectigo Certificate Manager server.
+type Server struct {
+	*httptest.Server
+
+	username     string
+	customer     string
+	password     string
+	autoIssue    bool
+	autoValidate bool
+	now          func() time.Time
+	serialNumber func() (*big.Int, error)
+	certTypes    []sectigo.CertType
+	customFields []sectigo.CustomFieldDefinition
+
+	caCert *x509.Certificate
+	caKey  *ecdsa.PrivateKey
+
+	mu            sync.Mutex
+	nextId        int
+	requests      int
+	faults        []*Fault
+	certificates  []*certificate
+	domains       []*domain
+	validations   []*validation
+	acmeAccounts  []*acmeAccount
+	organizations []sectigo.Organization
+}
+
+// NewServer starts a Server. The caller should call Close when finished, to shut it down.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		username:     DefaultUsername,
+		customer:     DefaultCustomer,
+		password:     DefaultPassword,
+		now:          time.Now,
+		serialNumber: randomSerialNumber,
+		certTypes:    []sectigo.CertType{{Id: 1, Name: "OV SSL", Terms: []int{365}}},
+		nextId:       1,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := s.initCA(); err != nil {
+		panic(fmt.Sprintf("sectigotest: error creating CA: %v", err))
+	}
+
+	mux := http.NewServeMux()
+	s.registerSSLHandlers(mux)
+	s.registerDomainHandlers(mux)
+	s.registerACMEHandlers(mux)
+	s.Server = httptest.NewServer(s.middleware(mux))
+
+	return s
+}
+
+// Config returns a client configuration for the server.
+func (s *Server) Config() sectigo.Config {
+	return sectigo.Config{
+		URL:      s.URL,
+		Username: s.username,
+		Customer: s.customer,
+		Password: s.password,
+	}
+}
+
+// Client returns a client configured for the server.
+func (s *Server) Client() *sectigo.Client {
+	return sectigo.NewClient(s.Config())
+}
+
+// CA returns the certificate of the CA issuing the certificates of the server.
+func (s *Server) CA() *x509.Certificate {
+	return s.caCert
+}
+
+// InjectFault adds a fault to the server. Faults are applied in the order they were injected.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all the faults of the server.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the number of requests received by the server, including the rejected ones.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// middleware counts the requests, applies the faults and checks the credentials.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fault := s.takeFault(r)
+		if fault != nil {
+			if fault.Latency > 0 {
+				timer := time.NewTimer(fault.Latency)
+				select {
+				case <-r.Context().Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+			}
+			if fault.StatusCode != 0 {
+				if fault.RetryAfter > 0 {
+					w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Seconds())))
+				}
+				writeError(w, fault.StatusCode, ErrorCodeFault, http.StatusText(fault.StatusCode))
+				return
+			}
+		}
+
+		if r.Header.Get("login") != s.username || r.Header.Get("customerUri") != s.customer || r.Header.Get("password") != s.password {
+			writeError(w, http.StatusUnauthorized, ErrorCodeUnauthorized, "Invalid credentials")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// takeFault counts r and returns the first fault matching it, if any.
+func (s *Server) takeFault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	for i, fault := range s.faults {
+		if !fault.matches(r) {
+			continue
+		}
+		if fault.Count > 0 {
+			fault.Count--
+			if fault.Count == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+// newId returns a new unique ID. s.mu must be held.
+func (s *Server) newId() int {
+	id := s.nextId
+	s.nextId++
+	return id
+}
+
+// today returns the current date of the server.
+func (s *Server) today() string {
+	return s.now().Format(dateLayout)
+}
+
+// initCA creates the CA issuing the certificates.
+func (s *Server) initCA() error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sectigotest CA"},
+		NotBefore:             s.now().Add(-time.Hour),
+		NotAfter:              s.now().AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return err
+	}
+
+	s.caCert, err = x509.ParseCertificate(der)
+	s.caKey = key
+	return err
+}
+
+// writeJSON writes v as a JSON response with status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in the format of the Sectigo API.
+func writeError(w http.ResponseWriter, status, code int, description string) {
+	writeJSON(w, status, map[string]any{"code": code, "description": description})
+}
+
+// decodeJSON decodes the JSON body of r into v, writing an error response if it is invalid.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, fmt.Sprintf("Invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+// pathId parses the integer path value name of r, writing an error response if it is invalid.
+func pathId(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue(name))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, fmt.Sprintf("Invalid %s", name))
+		return 0, false
+	}
+	return id, true
+}
+
+// queryInt returns the integer query parameter name of r, or 0 if it is missing or invalid.
+func queryInt(r *http.Request, name string) int {
+	value, _ := strconv.Atoi(r.URL.Query().Get(name))
+	return value
+}
+
+// writePage writes the page of items selected by the position and size query parameters of r, with the
+// total number of items in the X-Total-Count header. A missing or zero size selects all the items.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(len(items)))
+
+	position := min(max(queryInt(r, "position"), 0), len(items))
+	end := len(items)
+	if size := queryInt(r, "size"); size > 0 {
+		end = min(position+size, len(items))
+	}
+
+	page := items[position:end]
+	if page == nil {
+		page = []T{}
+	}
+	writeJSON(w, http.StatusOK, page)
+}
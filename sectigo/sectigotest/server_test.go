@@ -0,0 +1,342 @@
+package sectigotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+	"github.com/fgouteroux/sectigo-client/sectigo/csr"
+)
+
+func TestServer_CertificateLifecycle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	generated, err := csr.Generate(csr.Request{CommonName: "example.com", Hostnames: []string{"example.com", "www.example.com"}, KeyAlgorithm: "EC", KeyParam: "P-256"})
+	assert.NoError(t, err)
+
+	enrolled, err := client.EnrollSSL(ctx, sectigo.EnrollSSLRequest{OrgId: 1, CertType: 1, Term: 365, CSR: generated.CSR})
+	assert.NoError(t, err)
+
+	details, err := client.GetSSLDetails(ctx, enrolled.SSLId)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusRequested, details.Status)
+	assert.Equal(t, "example.com", details.CommonName)
+
+	_, err = client.CollectSSL(ctx, enrolled.SSLId, sectigo.CollectFormatPEM)
+	var apiErr *sectigo.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, ErrorCodeNotIssued, apiErr.Code)
+
+	assert.NoError(t, server.IssueCertificate(enrolled.SSLId))
+
+	collected, err := client.CollectSSL(ctx, enrolled.SSLId, sectigo.CollectFormatPEM)
+	assert.NoError(t, err)
+	assert.Len(t, collected.Certificates, 2)
+	assert.Equal(t, []string{"example.com", "www.example.com"}, collected.Certificates[0].DNSNames)
+	assert.NoError(t, collected.Certificates[0].CheckSignatureFrom(server.CA()))
+	assert.Equal(t, generated.PrivateKey.Public(), collected.Certificates[0].PublicKey)
+
+	collected, err = client.CollectSSL(ctx, enrolled.SSLId, sectigo.CollectFormatBin)
+	assert.NoError(t, err)
+	assert.Len(t, collected.Certificates, 2)
+
+	assert.NoError(t, client.RevokeSSLById(ctx, enrolled.SSLId, "key compromise"))
+	details, _ = client.GetSSLDetails(ctx, enrolled.SSLId)
+	assert.Equal(t, StatusRevoked, details.Status)
+
+	err = client.RevokeSSLById(ctx, enrolled.SSLId, "again")
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, ErrorCodeInvalidStatus, apiErr.Code)
+}
+
+func TestServer_RevokeByCertOddLengthSerialNumber(t *testing.T) {
+	server := NewServer(WithAutoIssue())
+	defer server.Close()
+	server.serialNumber = func() (*big.Int, error) { return big.NewInt(0xabc), nil }
+	client := server.Client()
+	ctx := context.Background()
+
+	generated, err := csr.Generate(csr.Request{CommonName: "example.com", KeyAlgorithm: "EC", KeyParam: "P-256"})
+	assert.NoError(t, err)
+	enrolled, err := client.EnrollSSL(ctx, sectigo.EnrollSSLRequest{OrgId: 1, CertType: 1, Term: 365, CSR: generated.CSR})
+	assert.NoError(t, err)
+	collected, err := client.CollectSSL(ctx, enrolled.SSLId, sectigo.CollectFormatPEM)
+	assert.NoError(t, err)
+
+	// The serial number lookup matches without falling back to the SHA-1 fingerprint.
+	listed, err := client.ListSSL(ctx, sectigo.ListSSLParams{SerialNumber: "0ABC"})
+	assert.NoError(t, err)
+	assert.Len(t, listed.SSLCertificates, 1)
+
+	assert.NoError(t, client.RevokeSSLByCert(ctx, collected.Certificates[0], sectigo.RevokeSSLRequest{Reason: "superseded"}))
+	details, err := client.GetSSLDetails(ctx, enrolled.SSLId)
+	assert.NoError(t, err)
+	assert.Equal(t, "0ABC", details.SerialNumber)
+	assert.Equal(t, StatusRevoked, details.Status)
+}
+
+func TestServer_AutoIssue(t *testing.T) {
+	server := NewServer(WithAutoIssue())
+	defer server.Close()
+
+	generated, err := csr.Generate(csr.Request{CommonName: "example.com", KeyAlgorithm: "EC", KeyParam: "P-256"})
+	assert.NoError(t, err)
+
+	response, err := server.Client().EnrollAndCollect(context.Background(), sectigo.EnrollSSLRequest{OrgId: 1, CertType: 1, Term: 365, CSR: generated.CSR}, sectigo.EnrollAndCollectOptions{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, response.Certificates)
+
+	details, ok := server.Certificate(response.SSLId)
+	assert.True(t, ok)
+	assert.Equal(t, StatusIssued, details.Status)
+}
+
+func TestServer_Pagination(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	for i := range 7 {
+		_, err := server.AddCertificate(sectigo.SSLDetails{CommonName: fmt.Sprintf("host%d.example.com", i)})
+		assert.NoError(t, err)
+	}
+
+	client := server.Client()
+	page, err := client.ListSSL(context.Background(), sectigo.ListSSLParams{Position: 5, Size: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 7, page.TotalCount)
+	assert.Len(t, page.SSLCertificates, 2)
+
+	all, err := client.ListAllSSL(context.Background(), sectigo.ListSSLParams{})
+	assert.NoError(t, err)
+	assert.Len(t, all, 7)
+
+	filtered, err := client.ListSSL(context.Background(), sectigo.ListSSLParams{CommonName: "host3.example.com", Size: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, filtered.TotalCount)
+}
+
+func TestServer_DomainUpdate(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	domainId, err := client.CreateDomainWithID(ctx, sectigo.DomainRequest{Name: "example.com", Active: true})
+	assert.NoError(t, err)
+
+	description := "managed as code"
+	delegations := []sectigo.DelegationRequest{{OrgId: 3, CertTypes: []string{"SSL"}}}
+	assert.NoError(t, client.UpdateDomain(ctx, domainId, sectigo.UpdateDomainRequest{Description: &description, Delegations: &delegations}))
+
+	details, err := client.GetDomainByName(ctx, "example.com.")
+	assert.NoError(t, err)
+	assert.Equal(t, domainId, details.ID)
+	assert.Equal(t, description, details.Description)
+	assert.Equal(t, DomainStateActive, details.State)
+	assert.Equal(t, DelegationActive, details.DelegationStatus)
+
+	for range 2 {
+		assert.NoError(t, client.SuspendDomain(ctx, domainId))
+	}
+	details, err = client.GetDomainDetails(ctx, domainId)
+	assert.NoError(t, err)
+	assert.Equal(t, DomainStateSuspended, details.State)
+	assert.Equal(t, description, details.Description)
+
+	assert.NoError(t, client.ActivateDomain(ctx, domainId))
+	details, err = client.GetDomainDetails(ctx, domainId)
+	assert.NoError(t, err)
+	assert.Equal(t, DomainStateActive, details.State)
+
+	_, err = client.GetDomainByName(ctx, "missing.example.com")
+	assert.True(t, errors.Is(err, sectigo.ErrNotFound))
+	assert.True(t, errors.Is(client.SuspendDomain(ctx, domainId+100), sectigo.ErrNotFound))
+}
+
+func TestServer_DomainLifecycle(t *testing.T) {
+	server := NewServer(WithAutoValidate())
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	orgId := server.AddOrganization(sectigo.Organization{Name: "Example"})
+	domainId, err := client.CreateDomainWithID(ctx, sectigo.DomainRequest{Name: "example.com", Active: true})
+	assert.NoError(t, err)
+
+	_, err = client.CreateDomainWithID(ctx, sectigo.DomainRequest{Name: "example.com"})
+	var apiErr *sectigo.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+
+	domains, err := client.ListAllDomain(ctx, sectigo.ListDomainParams{Name: "example.com"})
+	assert.NoError(t, err)
+	assert.Len(t, domains, 1)
+	assert.Equal(t, domainId, domains[0].ID)
+
+	assert.NoError(t, client.DelegateDomain(ctx, sectigo.DelegateDomainRequest{OrgId: orgId, CertTypes: []string{"SSL"}, DomainIds: []int{domainId}}))
+	details, _ := server.Domain(domainId)
+	assert.Equal(t, DelegationRequested, details.DelegationStatus)
+
+	assert.NoError(t, client.ApproveDelegation(ctx, domainId, sectigo.ApproveDelegationRequest{OrgId: orgId}))
+	details, _ = server.Domain(domainId)
+	assert.Equal(t, DelegationActive, details.DelegationStatus)
+
+	started, err := client.StartDomainCNameValidation(ctx, sectigo.StartDomainCNameValidationRequest{Domain: "example.com"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, started.Host)
+	assert.NotEmpty(t, started.Point)
+
+	submitted, err := client.SubmitDomainCNameValidation(ctx, sectigo.SubmitDomainCNameValidationRequest{Domain: "example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, DCVStatusValidated, submitted.Status)
+
+	status, err := client.GetDomainValidationStatus(ctx, sectigo.GetDomainValidationStatusRequest{Domain: "example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, DCVStatusValidated, status.Status)
+	assert.NotEmpty(t, status.ExpirationDate)
+
+	validations, err := client.ListAllDomainValidation(ctx, sectigo.ListDomainValidationParams{DcvStatus: DCVStatusValidated})
+	assert.NoError(t, err)
+	assert.Len(t, validations, 1)
+
+	assert.NoError(t, client.DeleteDomain(ctx, domainId))
+	_, ok := server.Domain(domainId)
+	assert.False(t, ok)
+}
+
+func TestServer_ValidationMethods(t *testing.T) {
+	server := NewServer(WithAutoValidate())
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	httpStarted, err := client.StartDomainHTTPSValidation(ctx, sectigo.StartDomainHTTPValidationRequest{Domain: "web.example.com"})
+	assert.NoError(t, err)
+	assert.Contains(t, httpStarted.URL, "https://web.example.com/.well-known/pki-validation/")
+	assert.NotEmpty(t, httpStarted.FileContents())
+
+	_, err = client.SubmitDomainHTTPValidation(ctx, sectigo.SubmitDomainHTTPValidationRequest{Domain: "web.example.com"})
+	var apiErr *sectigo.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, ErrorCodeInvalidStatus, apiErr.Code)
+
+	httpSubmitted, err := client.SubmitDomainHTTPSValidation(ctx, sectigo.SubmitDomainHTTPValidationRequest{Domain: "web.example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, DCVStatusValidated, httpSubmitted.Status)
+
+	txtStarted, err := client.StartDomainTXTValidation(ctx, sectigo.StartDomainTXTValidationRequest{Domain: "dns.example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "_dnsauth.dns.example.com.", txtStarted.Host)
+	txtSubmitted, err := client.SubmitDomainTXTValidation(ctx, sectigo.SubmitDomainTXTValidationRequest{Domain: "dns.example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, DCVStatusValidated, txtSubmitted.Status)
+
+	emailStarted, err := client.StartDomainEmailValidation(ctx, sectigo.StartDomainEmailValidationRequest{Domain: "mail.example.com"})
+	assert.NoError(t, err)
+	assert.Contains(t, emailStarted.Emails, "admin@mail.example.com")
+
+	_, err = client.SubmitDomainEmailValidation(ctx, sectigo.SubmitDomainEmailValidationRequest{Domain: "mail.example.com", Email: "user@mail.example.com"})
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, ErrorCodeInvalidInput, apiErr.Code)
+
+	emailSubmitted, err := client.SubmitDomainEmailValidation(ctx, sectigo.SubmitDomainEmailValidationRequest{Domain: "mail.example.com", Email: "admin@mail.example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, DCVStatusValidated, emailSubmitted.Status)
+	assert.Equal(t, DCVMethodEmail, server.DomainValidation("mail.example.com").DcvMethod)
+}
+
+func TestServer_ValidationExpires(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	server := NewServer(WithNow(func() time.Time { return now }))
+	defer server.Close()
+
+	server.ValidateDomain("example.com")
+	assert.Equal(t, DCVStatusValidated, server.DomainValidation("example.com").DcvStatus)
+
+	now = now.AddDate(2, 0, 0)
+	assert.Equal(t, DCVStatusExpired, server.DomainValidation("example.com").DcvStatus)
+}
+
+func TestServer_AcmeAccounts(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	orgId := server.AddOrganization(sectigo.Organization{Name: "Example"})
+	accountId := server.AddAcmeAccount(sectigo.AcmeAccount{Name: "acme", OrganizationID: orgId})
+	server.AddAcmeAccount(sectigo.AcmeAccount{Name: "other", OrganizationID: orgId + 100})
+
+	accounts, err := client.ListAcmeAccount(ctx, sectigo.ListAcmeAccountParams{OrganizationId: orgId, Size: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, accounts.TotalCount)
+	assert.Equal(t, "acme", accounts.Accounts[0].Name)
+
+	assert.NoError(t, client.AddAcmeAccountDomains(ctx, sectigo.AcmeAccountDomainParams{AccountID: accountId, Domains: []string{"example.com", "example.org"}}))
+	assert.Equal(t, []string{"example.com", "example.org"}, server.AcmeAccountDomains(accountId))
+
+	server.ValidateDomain("example.com")
+	domains, err := client.ListAllAcmeAccountDomain(ctx, sectigo.ListAcmeAccountDomainParams{AccountID: accountId})
+	assert.NoError(t, err)
+	assert.Len(t, domains, 2)
+	assert.NotEmpty(t, domains[0].ValidUntil)
+
+	err = client.AddAcmeAccountDomains(ctx, sectigo.AcmeAccountDomainParams{AccountID: 999, Domains: []string{"example.com"}})
+	var apiErr *sectigo.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
+
+func TestServer_Faults(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	config := server.Config()
+	config.Retry = sectigo.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+	client := sectigo.NewClient(config)
+
+	server.InjectFault(Fault{Path: "/api/organization/v1", StatusCode: http.StatusTooManyRequests, Count: 2})
+	_, err := client.ListOrganization(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, server.Requests())
+
+	server.InjectFault(Fault{Method: http.MethodGet, StatusCode: http.StatusInternalServerError})
+	_, err = client.ListOrganization(ctx)
+	var apiErr *sectigo.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	server.ClearFaults()
+
+	server.InjectFault(Fault{Latency: time.Second})
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = server.Client().ListOrganization(timeoutCtx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	server := NewServer(WithCredentials("user", "customer", "secret"))
+	defer server.Close()
+
+	config := server.Config()
+	config.Password = "wrong"
+	_, err := sectigo.NewClient(config).ListOrganization(context.Background())
+	var apiErr *sectigo.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.Equal(t, ErrorCodeUnauthorized, apiErr.Code)
+
+	_, err = server.Client().ListOrganization(context.Background())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,653 @@
+package sectigotest
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/fgouteroux/sectigo-client/sectigo"
+)
+
+// Certificate statuses used by the server.
+const (
+	StatusRequested = "Requested"
+	StatusApproved  = "Approved"
+	StatusIssued    = "Issued"
+	StatusRevoked   = "Revoked"
+	StatusDeclined  = "Declined"
+)
+
+// certificate is an SSL certificate held by the server.
+type certificate struct {
+	details sectigo.SSLDetails
+	renewId string
+	csr     string
+	der     []byte
+}
+
+// AddCertificate adds a certificate with the given details and returns its sslId. The certificate is
+// issued when details.Status is "Issued", and requested when it is empty.
+func (s *Server) AddCertificate(details sectigo.SSLDetails) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.newCertificate(details, "")
+	if details.Status == StatusIssued {
+		c.details.Status = StatusRequested
+		if err := s.issue(c); err != nil {
+			return 0, err
+		}
+	}
+	return c.details.SSLId, nil
+}
+
+// Certificate returns the details of the certificate with sslId.
+func (s *Server) Certificate(sslId int) (sectigo.SSLDetails, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findCertificate(sslId)
+	if c == nil {
+		return sectigo.SSLDetails{}, false
+	}
+	return c.details, true
+}
+
+// IssueCertificate issues the requested or approved certificate with sslId.
+func (s *Server) IssueCertificate(sslId int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findCertificate(sslId)
+	if c == nil {
+		return fmt.Errorf("certificate %d not found", sslId)
+	}
+	return s.issue(c)
+}
+
+// newCertificate adds a certificate with details and csr. s.mu must be held.
+func (s *Server) newCertificate(details sectigo.SSLDetails, csr string) *certificate {
+	id := s.newId()
+	details.SSLId = id
+	details.Id = id
+	if details.Status == "" {
+		details.Status = StatusRequested
+	}
+	if details.Requested == "" {
+		details.Requested = s.today()
+	}
+
+	c := &certificate{details: details, renewId: fmt.Sprintf("renew-%d", id), csr: csr}
+	s.certificates = append(s.certificates, c)
+	return c
+}
+
+// findCertificate returns the certificate with sslId, or nil. s.mu must be held.
+func (s *Server) findCertificate(sslId int) *certificate {
+	for _, c := range s.certificates {
+		if c.details.SSLId == sslId {
+			return c
+		}
+	}
+	return nil
+}
+
+// issue signs the certificate with the CA of the server. s.mu must be held.
+func (s *Server) issue(c *certificate) error {
+	if c.details.Status != StatusRequested && c.details.Status != StatusApproved {
+		return fmt.Errorf("certificate %d has status %s", c.details.SSLId, c.details.Status)
+	}
+
+	publicKey, commonName, names, err := parseCSR(c.csr)
+	if err != nil {
+		return err
+	}
+	if commonName == "" {
+		commonName = c.details.CommonName
+	}
+	if len(names) == 0 {
+		names = c.details.SubjectAlternativeNames
+	}
+	if publicKey == nil {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return err
+		}
+		publicKey = &key.PublicKey
+	}
+
+	serial, err := s.serialNumber()
+	if err != nil {
+		return err
+	}
+	term := c.details.Term
+	if term <= 0 {
+		term = 365
+	}
+	now := s.now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     names,
+		NotBefore:    now,
+		NotAfter:     now.AddDate(0, 0, term),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, s.caCert, publicKey, s.caKey)
+	if err != nil {
+		return err
+	}
+
+	sha1Hash := sha1.Sum(der) //nolint:gosec
+	c.der = der
+	c.details.Status = StatusIssued
+	c.details.CommonName = commonName
+	c.details.SubjectAlternativeNames = names
+	c.details.Term = term
+	c.details.Issued = now.Format(dateLayout)
+	c.details.Expires = template.NotAfter.Format(dateLayout)
+	c.details.SerialNumber = serialNumberHex(serial)
+	c.details.CertificateDetails = sectigo.CertificateDetails{
+		Issuer:          s.caCert.Subject.String(),
+		Subject:         template.Subject.String(),
+		SubjectAltNames: strings.Join(names, ","),
+		Sha1Hash:        strings.ToUpper(hex.EncodeToString(sha1Hash[:])),
+	}
+	return nil
+}
+
+// randomSerialNumber returns a random 64-bit certificate serial number.
+func randomSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+}
+
+// serialNumberHex returns serial in upper case hexadecimal, left-padded to an even length like SCM.
+func serialNumberHex(serial *big.Int) string {
+	serialNumber := strings.ToUpper(serial.Text(16))
+	if len(serialNumber)%2 == 1 {
+		serialNumber = "0" + serialNumber
+	}
+	return serialNumber
+}
+
+// parseCSR returns the public key, common name and DNS names of a PEM encoded CSR. An empty CSR returns no values.
+func parseCSR(csr string) (crypto.PublicKey, string, []string, error) {
+	if csr == "" {
+		return nil, "", nil, nil
+	}
+	block, _ := pem.Decode([]byte(csr))
+	if block == nil {
+		return nil, "", nil, fmt.Errorf("invalid CSR")
+	}
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("invalid CSR: %w", err)
+	}
+	return request.PublicKey, request.Subject.CommonName, request.DNSNames, nil
+}
+
+// registerSSLHandlers registers the handlers of the SSL certificate API.
+func (s *Server) registerSSLHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/ssl/v1/enroll", s.handleEnroll)
+	mux.HandleFunc("GET /api/ssl/v1", s.handleListSSL)
+	mux.HandleFunc("PUT /api/ssl/v1", s.handleUpdateSSL)
+	mux.HandleFunc("GET /api/ssl/v1/{id}", s.handleGetSSL)
+	mux.HandleFunc("GET /api/ssl/v1/collect/{id}/{format}", s.handleCollect)
+	mux.HandleFunc("POST /api/ssl/v1/approve/{id}", s.handleApproval(StatusApproved))
+	mux.HandleFunc("POST /api/ssl/v1/decline/{id}", s.handleApproval(StatusDeclined))
+	mux.HandleFunc("POST /api/ssl/v1/revoke/{id}", s.handleRevoke)
+	mux.HandleFunc("POST /api/ssl/v1/revoke/serial/{serial}", s.handleRevoke)
+	mux.HandleFunc("POST /api/ssl/v1/revoke/manual", s.handleMarkRevoked)
+	mux.HandleFunc("POST /api/ssl/v1/renewById/{id}", s.handleRenew)
+	mux.HandleFunc("POST /api/ssl/v1/renew/{renewId}", s.handleRenew)
+	mux.HandleFunc("POST /api/ssl/v1/replace/{id}", s.handleReplace)
+	mux.HandleFunc("GET /api/ssl/v1/types", s.handleListSSLTypes)
+	mux.HandleFunc("GET /api/ssl/v1/customFields", s.handleListCustomFields)
+}
+
+func (s *Server) handleEnroll(w http.ResponseWriter, r *http.Request) {
+	var request sectigo.EnrollSSLRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var certType sectigo.CertType
+	for _, t := range s.certTypes {
+		if t.Id == request.CertType {
+			certType = t
+		}
+	}
+	if certType.Id == 0 {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, fmt.Sprintf("Unknown certificate type %d", request.CertType))
+		return
+	}
+	if _, _, _, err := parseCSR(request.CSR); err != nil || request.CSR == "" {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "Invalid CSR")
+		return
+	}
+
+	c := s.newCertificate(sectigo.SSLDetails{
+		OrgId:             request.OrgId,
+		CertType:          certType,
+		Term:              request.Term,
+		Comments:          request.Comments,
+		ExternalRequester: request.ExternalRequester,
+		CustomFields:      request.CustomFields,
+		RequestedVia:      "REST API",
+	}, request.CSR)
+	c.details.CommonName, c.details.SubjectAlternativeNames = csrNames(request.CSR)
+	if request.SubjAltNames != "" {
+		c.details.SubjectAlternativeNames = strings.Split(request.SubjAltNames, ",")
+	}
+	if s.autoIssue {
+		if err := s.issue(c); err != nil {
+			writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, err.Error())
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, sectigo.EnrollSSLResponse{SSLId: c.details.SSLId, RenewId: c.renewId})
+}
+
+// csrNames returns the common name and DNS names of a valid CSR.
+func csrNames(csr string) (string, []string) {
+	_, commonName, names, _ := parseCSR(csr)
+	return commonName, names
+}
+
+func (s *Server) handleListSSL(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := []sectigo.SSLCertificate{}
+	for _, c := range s.certificates {
+		d := c.details
+		if (query.Has("commonName") && query.Get("commonName") != d.CommonName) ||
+			(query.Has("status") && query.Get("status") != d.Status) ||
+			(query.Has("orgId") && queryInt(r, "orgId") != d.OrgId) ||
+			(query.Has("sslTypeId") && queryInt(r, "sslTypeId") != d.CertType.Id) ||
+			(query.Has("serialNumber") && !strings.EqualFold(query.Get("serialNumber"), d.SerialNumber)) ||
+			(query.Has("sha1Hash") && !strings.EqualFold(query.Get("sha1Hash"), d.CertificateDetails.Sha1Hash)) ||
+			(query.Has("subjectAlternativeName") && !slices.Contains(d.SubjectAlternativeNames, query.Get("subjectAlternativeName"))) {
+			continue
+		}
+		items = append(items, sectigo.SSLCertificate{
+			SSLId:                   d.SSLId,
+			CommonName:              d.CommonName,
+			SubjectAlternativeNames: d.SubjectAlternativeNames,
+			SerialNumber:            d.SerialNumber,
+		})
+	}
+
+	writePage(w, r, items)
+}
+
+func (s *Server) handleGetSSL(w http.ResponseWriter, r *http.Request) {
+	c, unlock, ok := s.lockCertificate(w, r)
+	if !ok {
+		return
+	}
+	defer unlock()
+
+	writeJSON(w, http.StatusOK, c.details)
+}
+
+func (s *Server) handleUpdateSSL(w http.ResponseWriter, r *http.Request) {
+	var request sectigo.UpdateSSLDetailsRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findCertificate(request.SSLId)
+	if c == nil {
+		writeError(w, http.StatusNotFound, ErrorCodeNotFound, "Certificate not found")
+		return
+	}
+
+	d := &c.details
+	if request.Term != 0 {
+		d.Term = request.Term
+	}
+	if request.OrgId != 0 {
+		d.OrgId = request.OrgId
+	}
+	if request.CommonName != "" {
+		d.CommonName = request.CommonName
+	}
+	if request.CSR != "" {
+		c.csr = request.CSR
+	}
+	if request.ExternalRequester != "" {
+		d.ExternalRequester = request.ExternalRequester
+	}
+	if request.Comments != "" {
+		d.Comments = request.Comments
+	}
+	if request.SubjectAlternativeNames != nil {
+		d.SubjectAlternativeNames = request.SubjectAlternativeNames
+	}
+	if request.CustomFields != nil {
+		d.CustomFields = request.CustomFields
+	}
+	if request.AutoRenewDetails != nil {
+		d.AutoRenewDetails = *request.AutoRenewDetails
+	}
+	if request.Requester != "" {
+		d.Requester = request.Requester
+	}
+	d.SuspendNotifications = request.SuspendNotifications
+
+	writeJSON(w, http.StatusOK, c.details)
+}
+
+func (s *Server) handleCollect(w http.ResponseWriter, r *http.Request) {
+	c, unlock, ok := s.lockCertificate(w, r)
+	if !ok {
+		return
+	}
+	defer unlock()
+
+	if c.der == nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeNotIssued, "Certificate is being processed by Sectigo")
+		return
+	}
+
+	leaf := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der})
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.caCert.Raw})
+
+	var data []byte
+	switch sectigo.CollectFormat(r.PathValue("format")) {
+	case sectigo.CollectFormatX509, sectigo.CollectFormatPEM, sectigo.CollectFormatPEMIA:
+		data = append(leaf, ca...)
+	case sectigo.CollectFormatX509CO, sectigo.CollectFormatPEMCO:
+		data = leaf
+	case sectigo.CollectFormatX509IO, sectigo.CollectFormatX509IOR:
+		data = ca
+	case sectigo.CollectFormatBin:
+		data = buildPKCS7(c.der, s.caCert.Raw)
+	case sectigo.CollectFormatBase64:
+		data = []byte(base64.StdEncoding.EncodeToString(buildPKCS7(c.der, s.caCert.Raw)))
+	default:
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "Unsupported format")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = w.Write(data)
+}
+
+// handleApproval returns a handler setting the status of a requested certificate.
+func (s *Server) handleApproval(status string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c, unlock, ok := s.lockCertificate(w, r)
+		if !ok {
+			return
+		}
+		defer unlock()
+
+		if c.details.Status != StatusRequested {
+			writeError(w, http.StatusBadRequest, ErrorCodeInvalidStatus, fmt.Sprintf("Certificate has status %s", c.details.Status))
+			return
+		}
+		c.details.Status = status
+		if status == StatusDeclined {
+			c.details.Declined = s.today()
+		} else {
+			c.details.Approved = s.today()
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) handleRevoke(w http.ResponseWriter, r *http.Request) {
+	var request sectigo.RevokeSSLRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+	if request.Reason == "" {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "Reason is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var c *certificate
+	if serial := r.PathValue("serial"); serial != "" {
+		c = s.findCertificateBySerial(serial)
+	} else if id, ok := pathId(w, r, "id"); ok {
+		c = s.findCertificate(id)
+	} else {
+		return
+	}
+	if c == nil {
+		writeError(w, http.StatusNotFound, ErrorCodeNotFound, "Certificate not found")
+		return
+	}
+
+	if c.details.Status != StatusIssued {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidStatus, fmt.Sprintf("Certificate has status %s", c.details.Status))
+		return
+	}
+	s.revoke(c, request.ReasonCode)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleMarkRevoked(w http.ResponseWriter, r *http.Request) {
+	var request sectigo.MarkSSLAsRevokedRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findCertificate(request.CertId)
+	if request.CertId == 0 {
+		c = s.findCertificateBySerial(request.SerialNumber)
+	}
+	if c == nil {
+		writeError(w, http.StatusNotFound, ErrorCodeNotFound, "Certificate not found")
+		return
+	}
+
+	s.revoke(c, request.ReasonCode)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// revoke marks the certificate revoked. s.mu must be held.
+func (s *Server) revoke(c *certificate, reasonCode sectigo.RevocationReason) {
+	c.details.Status = StatusRevoked
+	c.details.Revoked = s.today()
+	c.details.ReasonCode = reasonCode
+}
+
+// findCertificateBySerial returns the certificate with the given serial number, or nil. s.mu must be held.
+func (s *Server) findCertificateBySerial(serial string) *certificate {
+	for _, c := range s.certificates {
+		if c.details.SerialNumber != "" && strings.EqualFold(c.details.SerialNumber, serial) {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleRenew(w http.ResponseWriter, r *http.Request) {
+	var request sectigo.RenewSSLRequest
+	if r.ContentLength != 0 && !decodeJSON(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var c *certificate
+	if renewId := r.PathValue("renewId"); renewId != "" {
+		for _, candidate := range s.certificates {
+			if candidate.renewId == renewId {
+				c = candidate
+			}
+		}
+	} else if id, ok := pathId(w, r, "id"); ok {
+		c = s.findCertificate(id)
+	} else {
+		return
+	}
+	if c == nil {
+		writeError(w, http.StatusNotFound, ErrorCodeNotFound, "Certificate not found")
+		return
+	}
+	if c.details.Status != StatusIssued {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidStatus, fmt.Sprintf("Certificate has status %s", c.details.Status))
+		return
+	}
+
+	csr := c.csr
+	if request.CSR != "" {
+		csr = request.CSR
+	}
+	renewed := s.newCertificate(sectigo.SSLDetails{
+		OrgId:                   c.details.OrgId,
+		CertType:                c.details.CertType,
+		Term:                    c.details.Term,
+		CommonName:              c.details.CommonName,
+		SubjectAlternativeNames: c.details.SubjectAlternativeNames,
+		Comments:                c.details.Comments,
+		ExternalRequester:       c.details.ExternalRequester,
+		CustomFields:            c.details.CustomFields,
+		RequestedVia:            "REST API",
+	}, csr)
+	c.details.Renewed = true
+	c.details.RenewedDate = s.today()
+	if s.autoIssue {
+		if err := s.issue(renewed); err != nil {
+			writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, err.Error())
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, sectigo.RenewSSLResponse{SSLId: renewed.details.SSLId})
+}
+
+func (s *Server) handleReplace(w http.ResponseWriter, r *http.Request) {
+	var request sectigo.ReplaceSSLRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	c, unlock, ok := s.lockCertificate(w, r)
+	if !ok {
+		return
+	}
+	defer unlock()
+
+	if c.details.Status != StatusIssued {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidStatus, fmt.Sprintf("Certificate has status %s", c.details.Status))
+		return
+	}
+
+	if request.CSR != "" {
+		c.csr = request.CSR
+	}
+	if request.CommonName != "" {
+		c.details.CommonName = request.CommonName
+	}
+	if request.SubjectAlternativeNames != "" {
+		c.details.SubjectAlternativeNames = strings.Split(request.SubjectAlternativeNames, ",")
+	}
+	c.der = nil
+	c.details.Status = StatusRequested
+	c.details.Replaced = s.today()
+	if s.autoIssue {
+		if err := s.issue(c); err != nil {
+			writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, err.Error())
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleListSSLTypes(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.certTypes)
+}
+
+func (s *Server) handleListCustomFields(w http.ResponseWriter, r *http.Request) {
+	customFields := s.customFields
+	if customFields == nil {
+		customFields = []sectigo.CustomFieldDefinition{}
+	}
+	writeJSON(w, http.StatusOK, customFields)
+}
+
+// lockCertificate locks the server and returns the certificate identified by the id path value, writing an
+// error response and unlocking the server if it does not exist.
+func (s *Server) lockCertificate(w http.ResponseWriter, r *http.Request) (*certificate, func(), bool) {
+	id, ok := pathId(w, r, "id")
+	if !ok {
+		return nil, nil, false
+	}
+
+	s.mu.Lock()
+	c := s.findCertificate(id)
+	if c == nil {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, ErrorCodeNotFound, "Certificate not found")
+		return nil, nil, false
+	}
+	return c, s.mu.Unlock, true
+}
+
+// buildPKCS7 encodes certificates in a degenerate PKCS#7 SignedData message.
+func buildPKCS7(certificates ...[]byte) []byte {
+	dataContentInfo, _ := asn1.Marshal(struct {
+		ContentType asn1.ObjectIdentifier
+	}{asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}})
+
+	signedData, _ := asn1.Marshal(struct {
+		Version          int
+		DigestAlgorithms asn1.RawValue
+		ContentInfo      asn1.RawValue
+		Certificates     asn1.RawValue
+		SignerInfos      asn1.RawValue
+	}{
+		Version:          1,
+		DigestAlgorithms: asn1.RawValue{Tag: asn1.TagSet, IsCompound: true},
+		ContentInfo:      asn1.RawValue{FullBytes: dataContentInfo},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: bytes.Join(certificates, nil)},
+		SignerInfos:      asn1.RawValue{Tag: asn1.TagSet, IsCompound: true},
+	})
+
+	der, _ := asn1.Marshal(struct {
+		ContentType asn1.ObjectIdentifier
+		Content     asn1.RawValue
+	}{
+		ContentType: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2},
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: signedData},
+	})
+
+	return der
+}
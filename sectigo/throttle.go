@@ -0,0 +1,101 @@
+package sectigo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// throttle enforces the client-side rate limit and concurrency cap on outgoing requests.
+type throttle struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	last     time.Time
+	inFlight chan struct{}
+	onWait   func(ctx context.Context, wait time.Duration)
+}
+
+// newThrottle creates a throttle from the client configuration, or returns nil when neither a rate limit
+// nor a concurrency cap is configured.
+func newThrottle(config Config) *throttle {
+	if config.RateLimit <= 0 && config.MaxInFlight <= 0 {
+		return nil
+	}
+
+	t := &throttle{
+		rate:   config.RateLimit,
+		burst:  float64(max(config.RateBurst, 1)),
+		onWait: config.OnThrottle,
+	}
+	t.tokens = t.burst
+	if config.MaxInFlight > 0 {
+		t.inFlight = make(chan struct{}, config.MaxInFlight)
+	}
+
+	return t
+}
+
+// acquire blocks until the request may be sent and returns a function releasing its in-flight slot.
+func (t *throttle) acquire(ctx context.Context) (func(), error) {
+	start := time.Now()
+
+	if t.rate > 0 {
+		if err := t.waitToken(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	release := func() {}
+	if t.inFlight != nil {
+		select {
+		case t.inFlight <- struct{}{}:
+			release = func() { <-t.inFlight }
+		case <-ctx.Done():
+			// Give the reserved token back since the request will not be sent.
+			if t.rate > 0 {
+				t.mu.Lock()
+				t.tokens++
+				t.mu.Unlock()
+			}
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.onWait != nil {
+		t.onWait(ctx, time.Since(start))
+	}
+
+	return release, nil
+}
+
+// waitToken reserves a token from the bucket and sleeps until it becomes available.
+func (t *throttle) waitToken(ctx context.Context) error {
+	t.mu.Lock()
+	now := time.Now()
+	if !t.last.IsZero() {
+		t.tokens = min(t.burst, t.tokens+now.Sub(t.last).Seconds()*t.rate)
+	}
+	t.last = now
+	t.tokens--
+	wait := time.Duration(-t.tokens / t.rate * float64(time.Second))
+	t.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the reserved token back since the request will not be sent.
+		t.mu.Lock()
+		t.tokens++
+		t.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,118 @@
+package sectigo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewThrottle_Disabled(t *testing.T) {
+	assert.Nil(t, newThrottle(Config{}))
+
+	client := NewClient(Config{URL: "https://cert-manager.com"})
+	assert.Nil(t, client.throttle)
+}
+
+func TestThrottle_RateLimit(t *testing.T) {
+	var waits []time.Duration
+	throttle := newThrottle(Config{
+		RateLimit: 50,
+		RateBurst: 2,
+		OnThrottle: func(ctx context.Context, wait time.Duration) {
+			waits = append(waits, wait)
+		},
+	})
+
+	start := time.Now()
+	for range 4 {
+		release, err := throttle.acquire(context.Background())
+		assert.NoError(t, err)
+		release()
+	}
+
+	// The burst covers two requests, the other two wait 20ms each.
+	assert.GreaterOrEqual(t, time.Since(start), 35*time.Millisecond)
+	assert.Equal(t, 4, len(waits))
+	assert.Less(t, waits[0], 10*time.Millisecond)
+	assert.Greater(t, waits[3], 10*time.Millisecond)
+}
+
+func TestThrottle_RateLimitContextCancelled(t *testing.T) {
+	throttle := newThrottle(Config{RateLimit: 0.1})
+
+	release, err := throttle.acquire(context.Background())
+	assert.NoError(t, err)
+	release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = throttle.acquire(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestThrottle_MaxInFlightContextCancelledRefundsToken(t *testing.T) {
+	throttle := newThrottle(Config{RateLimit: 0.1, RateBurst: 2, MaxInFlight: 1})
+
+	release, err := throttle.acquire(context.Background())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = throttle.acquire(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	release()
+
+	// The token reserved by the cancelled request is still available.
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	release, err = throttle.acquire(ctx)
+	assert.NoError(t, err)
+	release()
+}
+
+func TestClient_MaxInFlight(t *testing.T) {
+	mockClient := NewMockClient()
+	defer mockClient.Close()
+
+	var inFlight, maxInFlight int32
+	mockClient.Mux.HandleFunc("/api/organization/v1", func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[]`)) //nolint:errcheck
+	})
+
+	client := NewClient(Config{
+		URL:         mockClient.Server.URL,
+		Username:    "test",
+		Customer:    "test",
+		Password:    "test",
+		MaxInFlight: 2,
+	})
+
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.ListOrganization(context.Background())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, maxInFlight, int32(2))
+}
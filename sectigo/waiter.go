@@ -0,0 +1,171 @@
+package sectigo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// ErrWaitTimeout is matched through errors.Is by the WaitTimeoutError returned when a wait gives up.
+var ErrWaitTimeout = errors.New("wait timed out")
+
+// Backoff computes the wait between the polls of a Wait.
+type Backoff interface {
+	// Delay returns the wait after the given attempt, starting at 1.
+	Delay(attempt int) time.Duration
+}
+
+// BackoffFunc adapts a function to the Backoff interface.
+type BackoffFunc func(attempt int) time.Duration
+
+// Delay implements the Backoff interface.
+func (f BackoffFunc) Delay(attempt int) time.Duration {
+	return f(attempt)
+}
+
+// ConstantBackoff waits the same duration between every poll.
+type ConstantBackoff time.Duration
+
+// Delay implements the Backoff interface.
+func (b ConstantBackoff) Delay(attempt int) time.Duration {
+	return time.Duration(b)
+}
+
+// ExponentialBackoff multiplies the wait by Factor after every poll, from Initial up to Max.
+type ExponentialBackoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	// Factor defaults to 2 when below 1.
+	Factor float64
+	// Jitter is the fraction, between 0 and 1, of the wait that is randomized.
+	Jitter float64
+}
+
+// Delay implements the Backoff interface.
+func (b ExponentialBackoff) Delay(attempt int) time.Duration {
+	factor := b.Factor
+	if factor < 1 {
+		factor = 2
+	}
+
+	wait := b.Initial
+	for i := 1; i < attempt && (b.Max <= 0 || wait < b.Max); i++ {
+		wait = time.Duration(float64(wait) * factor)
+	}
+	if b.Max > 0 {
+		wait = min(wait, b.Max)
+	}
+
+	if jitter := min(max(b.Jitter, 0), 1); jitter > 0 && wait > 0 {
+		wait -= time.Duration(rand.Float64() * jitter * float64(wait))
+	}
+
+	return wait
+}
+
+// defaultWaitBackoff is the backoff used when WaitOptions.Backoff is not set.
+var defaultWaitBackoff = ExponentialBackoff{Initial: 10 * time.Second, Max: 5 * time.Minute, Factor: 2}
+
+// WaitProgress describes a poll of a Wait, passed to WaitOptions.OnProgress.
+type WaitProgress struct {
+	Attempt int
+	Elapsed time.Duration
+	// State is the state observed by the poll.
+	State string
+	// Next is the wait before the next poll.
+	Next time.Duration
+}
+
+// WaitOptions represents the options of a Wait.
+type WaitOptions struct {
+	// Backoff computes the wait between polls. Defaults to an exponential backoff from 10 seconds up to 5 minutes.
+	Backoff Backoff
+	// Timeout bounds the whole wait. 0 waits until the context is done.
+	Timeout time.Duration
+	// MaxAttempts bounds the number of polls. 0 polls until the timeout or the context is done.
+	MaxAttempts int
+	// OnProgress, when set, is called after every poll that did not end the wait.
+	OnProgress func(WaitProgress)
+}
+
+// WaitTimeoutError is returned when a wait gives up before reaching a terminal state, either because
+// its timeout or context expired or because its attempts were exhausted.
+type WaitTimeoutError struct {
+	Attempts  int
+	Elapsed   time.Duration
+	LastState string
+	// Err is the context error, nil when the attempts were exhausted.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *WaitTimeoutError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("max retries reached after %d attempts, last state: %s", e.Attempts, e.LastState)
+	}
+	return fmt.Sprintf("timed out after %d attempts in %s, last state: %s: %v", e.Attempts, e.Elapsed.Round(time.Millisecond), e.LastState, e.Err)
+}
+
+// Unwrap returns the context error.
+func (e *WaitTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrWaitTimeout.
+func (e *WaitTimeoutError) Is(target error) bool {
+	return target == ErrWaitTimeout
+}
+
+// WaitCondition polls the state of an operation. It returns the current value, a name for its state, and
+// whether the operation completed. Returning an error ends the wait, which is how terminal failures are reported.
+type WaitCondition[T any] func(ctx context.Context) (value T, state string, done bool, err error)
+
+// Wait polls condition until it reports completion or an error, sleeping between polls as computed by
+// options.Backoff. It returns a *WaitTimeoutError when the timeout, the context or the attempts run out.
+func Wait[T any](ctx context.Context, condition WaitCondition[T], options WaitOptions) (T, error) {
+	var zero T
+	backoff := options.Backoff
+	if backoff == nil {
+		backoff = defaultWaitBackoff
+	}
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	var state string
+	for attempt := 1; ; attempt++ {
+		value, currentState, done, err := condition(ctx)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+				return zero, &WaitTimeoutError{Attempts: attempt, Elapsed: time.Since(start), LastState: state, Err: ctxErr}
+			}
+			return zero, err
+		}
+		if done {
+			return value, nil
+		}
+		state = currentState
+
+		if options.MaxAttempts > 0 && attempt >= options.MaxAttempts {
+			return zero, &WaitTimeoutError{Attempts: attempt, Elapsed: time.Since(start), LastState: state}
+		}
+
+		delay := backoff.Delay(attempt)
+		if options.OnProgress != nil {
+			options.OnProgress(WaitProgress{Attempt: attempt, Elapsed: time.Since(start), State: state, Next: delay})
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, &WaitTimeoutError{Attempts: attempt, Elapsed: time.Since(start), LastState: state, Err: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+}
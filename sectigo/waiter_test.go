@@ -0,0 +1,93 @@
+package sectigo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff{Initial: time.Second, Max: 5 * time.Second, Factor: 2}
+	assert.Equal(t, time.Second, backoff.Delay(1))
+	assert.Equal(t, 2*time.Second, backoff.Delay(2))
+	assert.Equal(t, 4*time.Second, backoff.Delay(3))
+	assert.Equal(t, 5*time.Second, backoff.Delay(4))
+	assert.Equal(t, 5*time.Second, backoff.Delay(100))
+
+	backoff.Factor = 0
+	assert.Equal(t, 2*time.Second, backoff.Delay(2))
+
+	backoff.Jitter = 0.5
+	for attempt := 1; attempt < 5; attempt++ {
+		delay := backoff.Delay(attempt)
+		assert.LessOrEqual(t, delay, 5*time.Second)
+		assert.GreaterOrEqual(t, delay, 500*time.Millisecond)
+	}
+}
+
+func TestWait(t *testing.T) {
+	var progress []WaitProgress
+	polls := 0
+	value, err := Wait(context.Background(), func(ctx context.Context) (int, string, bool, error) {
+		polls++
+		return polls, "pending", polls == 3, nil
+	}, WaitOptions{
+		Backoff:    ConstantBackoff(time.Millisecond),
+		OnProgress: func(p WaitProgress) { progress = append(progress, p) },
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, value)
+	assert.Len(t, progress, 2)
+	assert.Equal(t, 2, progress[1].Attempt)
+	assert.Equal(t, "pending", progress[1].State)
+	assert.Equal(t, time.Millisecond, progress[1].Next)
+}
+
+func TestWait_ConditionError(t *testing.T) {
+	conditionErr := errors.New("rejected")
+	_, err := Wait(context.Background(), func(ctx context.Context) (int, string, bool, error) {
+		return 0, "", false, conditionErr
+	}, WaitOptions{})
+	assert.Equal(t, conditionErr, err)
+}
+
+func TestWait_MaxAttempts(t *testing.T) {
+	_, err := Wait(context.Background(), func(ctx context.Context) (int, string, bool, error) {
+		return 0, "pending", false, nil
+	}, WaitOptions{Backoff: ConstantBackoff(time.Millisecond), MaxAttempts: 3})
+
+	var timeoutErr *WaitTimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.True(t, errors.Is(err, ErrWaitTimeout))
+	assert.Equal(t, 3, timeoutErr.Attempts)
+	assert.Equal(t, "pending", timeoutErr.LastState)
+	assert.NoError(t, timeoutErr.Err)
+}
+
+func TestWait_Timeout(t *testing.T) {
+	start := time.Now()
+	_, err := Wait(context.Background(), func(ctx context.Context) (int, string, bool, error) {
+		return 0, "pending", false, nil
+	}, WaitOptions{Backoff: ConstantBackoff(time.Hour), Timeout: 20 * time.Millisecond})
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.True(t, errors.Is(err, ErrWaitTimeout))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), "last state: pending")
+}
+
+func TestWait_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := Wait(ctx, func(ctx context.Context) (int, string, bool, error) {
+		cancel()
+		return 0, "", false, ctx.Err()
+	}, WaitOptions{})
+
+	var timeoutErr *WaitTimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 1, timeoutErr.Attempts)
+}